@@ -0,0 +1,130 @@
+package twilio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxBodyBytes is the largest request body that will be read during
+// validation unless a Validator is configured otherwise with
+// WithMaxBodyBytes. Twilio's webhooks are far smaller than this.
+const DefaultMaxBodyBytes = 1 << 20
+
+// bufferBody reads up to limit bytes of the body of r into memory and
+// replaces r.Body with an unread copy. It also sets r.GetBody, so handlers
+// and anything else that consumes the body during validation can get a
+// fresh copy. A negative limit means no limit.
+func bufferBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	if limit >= 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, limit)
+	}
+	body, err := readAll(r.Body, r.ContentLength)
+	r.Body.Close()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, ErrBodyTooLarge
+		}
+		return nil, &FormError{err}
+	}
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	restoreBody(r)
+	return body, nil
+}
+
+// readAll is like io.ReadAll, but when the size of the body is known it
+// reads it into a buffer of the right size in one go.
+func readAll(body io.Reader, size int64) ([]byte, error) {
+	if size <= 0 || size > DefaultMaxBodyBytes {
+		return io.ReadAll(body)
+	}
+	// Allow one more byte so that reading the whole body hits EOF.
+	b := make([]byte, 0, size+1)
+	for {
+		n, err := body.Read(b[len(b):cap(b)])
+		b = b[:len(b)+n]
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return b, err
+		}
+		if len(b) == cap(b) {
+			// The body is longer than advertised.
+			rest, err := io.ReadAll(body)
+			return append(b, rest...), err
+		}
+	}
+}
+
+// restoreBody rewinds a body buffered by bufferBody.
+func restoreBody(r *http.Request) {
+	if r.GetBody != nil {
+		r.Body, _ = r.GetBody()
+	}
+}
+
+// isFormEncoded reports whether contentType is
+// application/x-www-form-urlencoded. It is a cheaper version of the check
+// postForm makes with mime.ParseMediaType.
+func isFormEncoded(contentType string) bool {
+	ct, _, _ := strings.Cut(contentType, ";")
+	return strings.EqualFold(strings.TrimSpace(ct), "application/x-www-form-urlencoded")
+}
+
+// postForm parses body the way http.Request.ParseForm would fill in
+// r.PostForm, without consuming r.Body.
+func postForm(r *http.Request, body []byte) (url.Values, error) {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct != "application/x-www-form-urlencoded" {
+		return url.Values{}, nil
+	}
+	vals, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, &FormError{err}
+	}
+	return vals, nil
+}
+
+// parsePairs appends the POST parameters in body to pairs, without building
+// a url.Values. By default it decodes body as url.ParseQuery would. If raw
+// is set it decodes body the way Twilio encodes it instead: pairs are split
+// on '&' alone, so unlike url.ParseQuery it keeps pairs that contain
+// semicolons.
+func parsePairs(pairs urlValues, r *http.Request, body []byte, raw bool) (urlValues, error) {
+	if !isFormEncoded(r.Header.Get("Content-Type")) {
+		return pairs, nil
+	}
+	s := string(body)
+	for s != "" {
+		var pair string
+		pair, s, _ = strings.Cut(s, "&")
+		if pair == "" {
+			continue
+		}
+		if !raw && strings.Contains(pair, ";") {
+			return nil, &FormError{errors.New("invalid semicolon separator in query")}
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(name)
+		if err != nil {
+			return nil, &FormError{err}
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return nil, &FormError{err}
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+	return pairs, nil
+}
@@ -0,0 +1,92 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestBodyPreserved(t *testing.T) {
+	const want = "CallSid=CA1234567890ABCDE&Caller=%2B14158675309&Digits=1234&From=%2B14158675309&To=%2B18005551212"
+
+	handler := twilio.Validate("12345", func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil || string(b) != want {
+			t.Errorf("handler read body %q, %v; want %q", b, err, want)
+		}
+		if r.GetBody == nil {
+			t.Fatal("GetBody is not set")
+		}
+		body, _ := r.GetBody()
+		if b, _ := io.ReadAll(body); string(b) != want {
+			t.Errorf("GetBody returned %q, want %q", b, want)
+		}
+	})
+	w := httptest.NewRecorder()
+	handler(w, exampleRequest())
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, want 200", w.Code)
+	}
+}
+
+func TestBodyPreservedAfterTokenProvider(t *testing.T) {
+	// AccountTokens parses the form itself; validation must still see the body.
+	r := exampleRequest()
+	r.URL.RawQuery += "&AccountSid=AC123"
+	r.Header.Set("X-Twilio-Signature", sign("12345", r.URL.String()+exampleParams))
+	v := twilio.New("", twilio.WithTokenProvider(twilio.AccountTokens(
+		func(ctx context.Context, accountSid string) ([]byte, error) {
+			return []byte("12345"), nil
+		})))
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if r.PostFormValue("Digits") != "1234" {
+		t.Error("handler should still be able to parse the form")
+	}
+}
+
+func TestRawCanonicalization(t *testing.T) {
+	// url.ParseQuery refuses bodies containing semicolons, so the default
+	// mode can't validate this request at all.
+	target := "https://mycompany.com/sms?a=%7Eb"
+	r, _ := http.NewRequest("POST", target, strings.NewReader("Body=one;two&From=%2B14158675309"))
+	r.RequestURI = "/sms?a=%7Eb"
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature", sign("12345", target+"Bodyone;twoFrom+14158675309"))
+
+	if err := twilio.New("12345").ValidateRequest(r); err == nil {
+		t.Error("default canonicalization should fail to parse the body")
+	}
+	restore(r)
+	if err := twilio.New("12345", twilio.RawCanonicalization()).ValidateRequest(r); err != nil {
+		t.Errorf("raw canonicalization: got %v, want nil", err)
+	}
+}
+
+// restore rewinds a request body that has already been validated.
+func restore(r *http.Request) {
+	r.Body, _ = r.GetBody()
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	if err := twilio.New("12345", twilio.WithMaxBodyBytes(16)).ValidateRequest(exampleRequest()); err != twilio.ErrBodyTooLarge {
+		t.Errorf("got %v, want ErrBodyTooLarge", err)
+	}
+	if err := twilio.New("12345", twilio.WithMaxBodyBytes(-1)).ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("with no limit: got %v, want nil", err)
+	}
+
+	r := exampleRequest()
+	r.Body = io.NopCloser(strings.NewReader(strings.Repeat("a", twilio.DefaultMaxBodyBytes+1)))
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != twilio.ErrBodyTooLarge {
+		t.Errorf("package-level: got %v, want ErrBodyTooLarge", err)
+	}
+}
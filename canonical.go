@@ -0,0 +1,181 @@
+package twilio
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// A urlVariant appends to dst the forms of the candidate signed URL u that
+// Twilio may have signed instead of u. It may append nothing.
+type urlVariant func(dst []string, r *http.Request, u string) []string
+
+// candidateURLs appends to dst every URL that r may have been signed with:
+// signedURL after any configured rewrites, and every form of it produced by
+// the configured variants. Each variant is applied to all the candidates
+// produced so far, so variants combine. The default port variant is always
+// applied last.
+func (sg signing) candidateURLs(dst []string, r *http.Request, signedURL string) []string {
+	if sg.raw {
+		signedURL = rawURL(r, signedURL)
+	}
+	for _, f := range sg.rewrites {
+		signedURL = f(signedURL)
+	}
+	dst = append(dst, signedURL)
+	for _, f := range sg.variants {
+		dst = expand(dst, r, f)
+	}
+	return expand(dst, r, portVariant)
+}
+
+// expand adds the variants f produces for each of urls, skipping
+// duplicates.
+func expand(urls []string, r *http.Request, f urlVariant) []string {
+	n := len(urls)
+	for i := 0; i < n; i++ {
+		start := len(urls)
+		urls = f(urls, r, urls[i])
+		kept := urls[:start]
+		for _, u := range urls[start:] {
+			if u != "" && !slices.Contains(kept, u) {
+				kept = append(kept, u)
+			}
+		}
+		urls = kept
+	}
+	return urls
+}
+
+// TryTrailingSlash makes the Validator also accept signatures over the
+// signed URL with a trailing slash added to its path, or removed from it if
+// it has one. Use it when a load balancer or framework adds or strips
+// trailing slashes, so that the path your handler sees differs from the
+// webhook URL configured in Twilio.
+func TryTrailingSlash() Option {
+	return withVariant(slashVariant)
+}
+
+// TryRawURL makes the Validator accept signatures over either the signed
+// URL as Go re-encodes it or the request target exactly as it arrived. It
+// is a more lenient form of RawCanonicalization, for deployments where a
+// frontend normalizes percent-encoding in some requests but not others.
+func TryRawURL() Option {
+	return withVariant(func(dst []string, r *http.Request, u string) []string {
+		return append(dst, rawURL(r, u))
+	})
+}
+
+// TrySchemes makes the Validator accept signatures over the signed URL with
+// its scheme replaced by any of schemes. With no arguments it tries https
+// and http. Use it behind TLS-terminating proxies that don't say which
+// scheme the client used, rather than guessing which one Twilio signed.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.TrySchemes())
+func TrySchemes(schemes ...string) Option {
+	if len(schemes) == 0 {
+		schemes = []string{"https", "http"}
+	}
+	return withVariant(func(dst []string, _ *http.Request, u string) []string {
+		_, rest, ok := strings.Cut(u, "://")
+		if !ok {
+			return dst
+		}
+		for _, scheme := range schemes {
+			dst = append(dst, scheme+"://"+rest)
+		}
+		return dst
+	})
+}
+
+func withVariant(f urlVariant) Option {
+	return func(v *Validator) {
+		v.signing.variants = append(v.signing.variants, f)
+	}
+}
+
+// slashVariant appends u with the trailing slash of its path toggled.
+func slashVariant(dst []string, _ *http.Request, u string) []string {
+	origin, path, rest := splitURL(u)
+	if p, ok := strings.CutSuffix(path, "/"); ok {
+		return append(dst, origin+p+rest)
+	}
+	return append(dst, origin+path+"/"+rest)
+}
+
+// StripPrefix makes the Validator remove prefix from the start of the path
+// of the signed URL before checking the signature. Use it when a gateway
+// adds a path prefix to requests on their way to your server, so that a
+// webhook Twilio calls as /sms arrives as /webhooks/sms. It only affects the
+// signature; routing still sees the path the request arrived with. Paths
+// that don't start with prefix are left alone.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.StripPrefix("/webhooks"))
+func StripPrefix(prefix string) Option {
+	prefix = cleanPrefix(prefix)
+	return withRewrite(func(u string) string {
+		origin, path, rest := splitURL(u)
+		p, ok := strings.CutPrefix(path, prefix)
+		if !ok || (p != "" && p[0] != '/') {
+			return u
+		}
+		if p == "" {
+			p = "/"
+		}
+		return origin + p + rest
+	})
+}
+
+// AddPrefix makes the Validator add prefix to the start of the path of the
+// signed URL before checking the signature. Use it when a gateway strips a
+// path prefix, such as an API Gateway stage name, so that a webhook Twilio
+// calls as /prod/sms arrives as /sms. It only affects the signature; routing
+// still sees the path the request arrived with.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.AddPrefix("/prod"))
+func AddPrefix(prefix string) Option {
+	prefix = cleanPrefix(prefix)
+	return withRewrite(func(u string) string {
+		origin, path, rest := splitURL(u)
+		return origin + prefix + path + rest
+	})
+}
+
+func withRewrite(f func(string) string) Option {
+	return func(v *Validator) {
+		v.signing.rewrites = append(v.signing.rewrites, f)
+	}
+}
+
+// cleanPrefix makes prefix start with a slash and end without one. The
+// empty prefix stays empty.
+func cleanPrefix(prefix string) string {
+	if prefix = strings.Trim(prefix, "/"); prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// splitURL splits an absolute URL into its scheme and host, its path, and
+// its query and fragment.
+func splitURL(u string) (origin, path, rest string) {
+	hostStart := 0
+	if i := strings.Index(u, "://"); i >= 0 {
+		hostStart = i + len("://")
+	}
+	pathStart := len(u)
+	if i := strings.IndexAny(u[hostStart:], "/?#"); i >= 0 {
+		pathStart = hostStart + i
+	}
+	pathEnd := len(u)
+	if i := strings.IndexAny(u[pathStart:], "?#"); i >= 0 {
+		pathEnd = pathStart + i
+	}
+	return u[:pathStart], u[pathStart:pathEnd], u[pathEnd:]
+}
@@ -0,0 +1,98 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestTryTrailingSlash(t *testing.T) {
+	tests := []struct {
+		signed, received string
+	}{
+		{"https://example.com/sms/", "https://example.com/sms"},
+		{"https://example.com/sms", "https://example.com/sms/"},
+		{"https://example.com/sms/?a=b", "https://example.com/sms?a=b"},
+		{"https://example.com:443/sms/", "https://example.com/sms"},
+		{"https://example.com", "https://example.com/"},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("GET", test.received, nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", test.signed))
+		if twilio.New("12345").IsValid(r) {
+			t.Errorf("signed %s, received %s: valid without TryTrailingSlash", test.signed, test.received)
+		}
+		if err := twilio.New("12345", twilio.TryTrailingSlash()).ValidateRequest(r); err != nil {
+			t.Errorf("signed %s, received %s: got %v, want nil", test.signed, test.received, err)
+		}
+	}
+}
+
+func TestTryRawURL(t *testing.T) {
+	// A frontend normalized the percent-encoding in the path, but Twilio
+	// signed the URL as it was configured.
+	r, _ := http.NewRequest("GET", "https://example.com/caf%C3%A9", nil)
+	r.RequestURI = "/caf%c3%a9"
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://example.com/caf%c3%a9"))
+	if twilio.New("12345").IsValid(r) {
+		t.Error("valid without TryRawURL")
+	}
+	if err := twilio.New("12345", twilio.TryRawURL()).ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	// The re-encoded form is still accepted.
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://example.com/caf%C3%A9"))
+	if err := twilio.New("12345", twilio.TryRawURL()).ValidateRequest(r); err != nil {
+		t.Errorf("re-encoded URL: got %v, want nil", err)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	tests := []struct {
+		opt              twilio.Option
+		signed, received string
+		valid            bool
+	}{
+		{twilio.AddPrefix("/prod"), "https://example.com/prod/sms?a=b", "https://example.com/sms?a=b", true},
+		{twilio.AddPrefix("prod/"), "https://example.com/prod/sms", "https://example.com/sms", true},
+		{twilio.AddPrefix("/prod"), "https://example.com/sms", "https://example.com/sms", false},
+		{twilio.StripPrefix("/webhooks"), "https://example.com/sms?a=b", "https://example.com/webhooks/sms?a=b", true},
+		{twilio.StripPrefix("/webhooks"), "https://example.com/", "https://example.com/webhooks", true},
+		{twilio.StripPrefix("/webhooks"), "https://example.com/webhooks-old/sms", "https://example.com/webhooks-old/sms", true},
+		{twilio.StripPrefix("/webhooks"), "https://example.com/webhooks/sms", "https://example.com/webhooks/sms", false},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("GET", test.received, nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", test.signed))
+		if got := twilio.New("12345", test.opt).IsValid(r); got != test.valid {
+			t.Errorf("signed %s, received %s: valid = %v, want %v", test.signed, test.received, got, test.valid)
+		}
+		if r.URL.String() != test.received {
+			t.Errorf("request URL changed to %s", r.URL)
+		}
+	}
+}
+
+func TestTrySchemes(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.com/sms", nil)
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://example.com/sms"))
+	if twilio.New("12345").IsValid(r) {
+		t.Error("valid without TrySchemes")
+	}
+	if err := twilio.New("12345", twilio.TrySchemes()).ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if twilio.New("12345", twilio.TrySchemes("http")).IsValid(r) {
+		t.Error("TrySchemes(\"http\") should not try https")
+	}
+
+	// Default ports follow the scheme.
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://example.com:443/sms"))
+	if err := twilio.New("12345", twilio.TrySchemes()).ValidateRequest(r); err != nil {
+		t.Errorf("with :443: got %v, want nil", err)
+	}
+}
@@ -0,0 +1,65 @@
+package twilio
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+// ClientCertTLSConfig returns a TLS configuration for an http.Server that
+// receives webhooks from Twilio with TLS client certificates enabled. It
+// verifies client certificates against the CA certificates in caPEM, which
+// should hold Twilio's client CA.
+//
+// Clients are not required to present a certificate at the TLS layer, so
+// health checks and other traffic can still connect. Use RequireClientCert
+// to reject webhook requests that didn't present a verified certificate.
+//
+// Example usage:
+//
+//	cfg, err := twilio.ClientCertTLSConfig(twilioCAPEM)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	srv := &http.Server{Addr: ":443", TLSConfig: cfg}
+//	log.Fatal(srv.ListenAndServeTLS(certFile, keyFile))
+func ClientCertTLSConfig(caPEM []byte) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("twilio: no CA certificates found in PEM data")
+	}
+	return &tls.Config{
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// RequireClientCert makes the Validator reject requests that didn't present
+// a client certificate verified by the server's TLS configuration (see
+// ClientCertTLSConfig). Such requests fail validation with ErrClientCert.
+// The signature is still checked as usual.
+//
+// If commonNames are given, the certificate's subject common name must also
+// be one of them.
+func RequireClientCert(commonNames ...string) Option {
+	return func(v *Validator) {
+		v.clientCert = true
+		v.clientCertNames = commonNames
+	}
+}
+
+func (v *Validator) checkClientCert(r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ErrClientCert
+	}
+	if len(v.clientCertNames) == 0 {
+		return nil
+	}
+	if !slices.Contains(v.clientCertNames, r.TLS.VerifiedChains[0][0].Subject.CommonName) {
+		return ErrClientCert
+	}
+	return nil
+}
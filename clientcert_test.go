@@ -0,0 +1,74 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestClientCertTLSConfig(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := twilio.ClientCertTLSConfig(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ClientAuth != tls.VerifyClientCertIfGiven || cfg.ClientCAs == nil {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	if _, err := twilio.ClientCertTLSConfig([]byte("not a certificate")); err == nil {
+		t.Error("ClientCertTLSConfig should fail without any certificates")
+	}
+}
+
+func TestRequireClientCert(t *testing.T) {
+	withCert := func(cn string) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+			{Subject: pkix.Name{CommonName: cn}},
+		}}}
+	}
+	tests := []struct {
+		tls   *tls.ConnectionState
+		names []string
+		want  error
+	}{
+		{nil, nil, twilio.ErrClientCert},
+		{&tls.ConnectionState{}, nil, twilio.ErrClientCert},
+		{withCert("anything"), nil, nil},
+		{withCert("voice.twilio.com"), []string{"voice.twilio.com"}, nil},
+		{withCert("example.com"), []string{"voice.twilio.com"}, twilio.ErrClientCert},
+	}
+	for i, test := range tests {
+		r := exampleRequest()
+		r.TLS = test.tls
+		if err := twilio.New("12345", twilio.RequireClientCert(test.names...)).ValidateRequest(r); err != test.want {
+			t.Errorf("%d: got %v, want %v", i, err, test.want)
+		}
+	}
+}
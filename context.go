@@ -0,0 +1,68 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+)
+
+// A Result describes the outcome of validating a request. Handlers wrapped
+// by a Validator can retrieve it with FromContext.
+type Result struct {
+	// Valid reports whether the request is a genuine Twilio request.
+	Valid bool
+
+	// Skipped reports that the request was not checked at all, because the
+	// Validator was created with InsecureSkipValidation or the request
+	// matched a SkipWhen predicate.
+	Skipped bool
+
+	// Err is the reason validation failed, or nil if it succeeded.
+	Err error
+
+	// TokenIndex identifies the auth token that signed the request: 0 for
+	// the token passed to New (or supplied by its TokenProvider), and 1 on
+	// for the tokens passed to WithAdditionalTokens. It is -1 if the request
+	// is not valid.
+	TokenIndex int
+
+	// Params holds the parameters of a valid or skipped request: its POST
+	// form and query string, combined as in http.Request.Form.
+	Params url.Values
+}
+
+func invalid(err error) *Result {
+	return &Result{Err: err, TokenIndex: -1}
+}
+
+// mergeParams combines a query string and POST form the way
+// http.Request.ParseForm does, with form values first.
+func mergeParams(query, form url.Values) url.Values {
+	for k, vs := range form {
+		query[k] = append(vs, query[k]...)
+	}
+	return query
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries res, for FromContext and
+// the decoders in the webhook package to find. A Validator's middleware
+// does this itself; NewContext is for adapters that validate with Check.
+func NewContext(ctx context.Context, res *Result) context.Context {
+	return context.WithValue(ctx, contextKey{}, res)
+}
+
+// FromContext returns the Result stored in ctx by a Validator's middleware.
+// It returns false if the request did not pass through a Validator.
+//
+// Example usage:
+//
+//	func myTwiMLHandler(w http.ResponseWriter, r *http.Request) {
+//		res, _ := twilio.FromContext(r.Context())
+//		digits := res.Params.Get("Digits")
+//		...
+//	}
+func FromContext(ctx context.Context) (*Result, bool) {
+	res, ok := ctx.Value(contextKey{}).(*Result)
+	return res, ok
+}
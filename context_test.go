@@ -0,0 +1,47 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestFromContext(t *testing.T) {
+	var res *twilio.Result
+	record := func(w http.ResponseWriter, r *http.Request) {
+		res, _ = twilio.FromContext(r.Context())
+	}
+
+	v := twilio.New("55555", twilio.WithAdditionalTokens("12345"),
+		twilio.WithFailureHandler(http.HandlerFunc(record)))
+
+	v.Validate(record)(httptest.NewRecorder(), exampleRequest())
+	if res == nil || !res.Valid {
+		t.Fatalf("got %+v, want a valid result", res)
+	}
+	if res.TokenIndex != 1 {
+		t.Errorf("TokenIndex = %d, want 1", res.TokenIndex)
+	}
+	if got := res.Params.Get("Digits"); got != "1234" {
+		t.Errorf("Params Digits = %q, want 1234", got)
+	}
+	if got := res.Params.Get("foo"); got != "1" {
+		t.Errorf("Params foo = %q, want 1", got)
+	}
+
+	res = nil
+	r := exampleRequest()
+	r.Header.Del("X-Twilio-Signature")
+	v.Validate(record)(httptest.NewRecorder(), r)
+	if res == nil || res.Valid || res.Err != twilio.ErrMissingSignature {
+		t.Errorf("got %+v, want an invalid result with ErrMissingSignature", res)
+	}
+
+	if _, ok := twilio.FromContext(exampleRequest().Context()); ok {
+		t.Error("FromContext should report false for a request that wasn't validated")
+	}
+}
@@ -0,0 +1,92 @@
+package twilio
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+)
+
+// A DebugHook is told the details of every signature mismatch, to help
+// diagnose requests that fail validation because the server's view of the
+// URL or parameters differs from Twilio's, as often happens behind proxies.
+//
+// The information passed to a DebugHook never includes an auth token, but
+// it does include request parameters, so take care where you log it.
+type DebugHook interface {
+	SignatureMismatch(r *http.Request, info MismatchInfo)
+}
+
+// The DebugHookFunc type is an adapter to allow the use of ordinary
+// functions as DebugHooks.
+type DebugHookFunc func(r *http.Request, info MismatchInfo)
+
+// SignatureMismatch calls f(r, info).
+func (f DebugHookFunc) SignatureMismatch(r *http.Request, info MismatchInfo) {
+	f(r, info)
+}
+
+// MismatchInfo describes a request whose signature didn't match.
+type MismatchInfo struct {
+	// Received is the signature header sent with the request.
+	Received string
+
+	// Attempts lists every signature that was computed for the request,
+	// one for each combination of candidate URL and auth token.
+	Attempts []SignatureAttempt
+}
+
+// A SignatureAttempt is one signature computed while validating a request.
+type SignatureAttempt struct {
+	// Canonical is the string that was signed: the URL followed by the
+	// sorted POST parameters. Any password in the URL is redacted.
+	Canonical string
+
+	// Computed is the Base64-encoded signature of Canonical, as it was
+	// before redaction.
+	Computed string
+
+	// TokenFingerprint identifies the auth token used without revealing
+	// it: the first 8 hex digits of the token's SHA-256 hash.
+	TokenFingerprint string
+}
+
+// WithDebugHook makes the Validator report signature mismatches to h.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithDebugHook(twilio.DebugHookFunc(
+//		func(r *http.Request, info twilio.MismatchInfo) {
+//			for _, a := range info.Attempts {
+//				log.Printf("signed %q as %s with token %s; got %s",
+//					a.Canonical, a.Computed, a.TokenFingerprint, info.Received)
+//			}
+//		})))
+func WithDebugHook(h DebugHook) Option {
+	return func(v *Validator) { v.signing.debug = h }
+}
+
+// mismatchInfo recomputes the signatures validateRequest tried, for a
+// DebugHook. It only runs after validation has failed, so it doesn't need
+// to be fast.
+func mismatchInfo(newMAC func(key []byte) hash.Hash, keys [][]byte, urls []string, params, received string) MismatchInfo {
+	info := MismatchInfo{Received: received}
+	for _, u := range urls {
+		for _, key := range keys {
+			mac := newMAC(key)
+			mac.Write([]byte(u + params))
+			info.Attempts = append(info.Attempts, SignatureAttempt{
+				Canonical:        redactURL(u) + params,
+				Computed:         base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+				TokenFingerprint: fingerprint(key),
+			})
+		}
+	}
+	return info
+}
+
+func fingerprint(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,55 @@
+// Code generated by gen.go from https://www.twilio.com/docs/api/errors/twilio-error-codes.json; DO NOT EDIT.
+
+package errorcode
+
+var catalog = map[Code]entry{
+	11100: {"Invalid URL format", "The URL Twilio was given for a webhook is not a valid absolute URL.", "Voice"},
+	11200: {"HTTP retrieval failure", "Twilio could not get a valid response from your webhook: the request failed, timed out or returned an error status.", "Voice"},
+	11205: {"HTTP connection failure", "Twilio could not connect to the server hosting your webhook.", "Voice"},
+	11206: {"HTTP protocol violation", "Your server's response to a webhook request was not valid HTTP.", "Voice"},
+	11210: {"HTTP bad host name", "The host name of your webhook URL could not be resolved.", "Voice"},
+	11215: {"HTTP too many redirects", "Your webhook redirected Twilio more times than it follows.", "Voice"},
+	11220: {"SSL/TLS Handshake Error", "Twilio could not complete a TLS handshake with the server hosting your webhook.", "Voice"},
+	11237: {"Certificate Invalid - Domain Mismatch", "The TLS certificate of the server hosting your webhook does not match its host name.", "Voice"},
+	11750: {"TwiML response body too large", "Your webhook's response was larger than Twilio accepts.", "Voice"},
+	12100: {"Document parse failure", "Twilio could not parse your webhook's response as TwiML.", "Voice"},
+	12200: {"Schema validation warning", "Your TwiML does not conform to the TwiML schema; an element or attribute may be misspelled or misplaced.", "Voice"},
+	12300: {"Invalid Content-Type", "Your webhook's response had a Content-Type Twilio does not accept, such as text/html.", "Voice"},
+	13214: {"Dial: Invalid callerId value", "The callerId of a Dial is not a phone number or client identity the account may use.", "Voice"},
+	13224: {"Dial: Twilio does not support calling this number or the number is invalid", "The number a Dial tried to call is not a valid, callable phone number.", "Voice"},
+	13225: {"Dial: Forbidden phone number", "Twilio does not allow calls to the number a Dial tried to call, such as a premium rate number.", "Voice"},
+	13227: {"Dial: No International Authorization", "The account's geographic permissions do not allow calls to the country a Dial tried to call.", "Voice"},
+	13520: {"Say: Invalid text", "A Say has no text, or text the voice could not speak.", "Voice"},
+	15003: {"Call Progress: Warning Response to Callback URL", "A call's status callback URL returned an error status.", "Voice"},
+	20003: {"Permission Denied", "The request was not authenticated, or its credentials may not access the resource.", "API"},
+	20404: {"Not Found", "The requested resource does not exist.", "API"},
+	20429: {"Too Many Requests", "The account made more concurrent or frequent requests than its limits allow.", "API"},
+	21211: {"Invalid 'To' Phone Number", "The To number is not a valid phone number.", "Programmable Messaging"},
+	21212: {"Invalid 'From' Phone Number", "The From number is not a valid phone number or alphanumeric sender ID.", "Programmable Messaging"},
+	21408: {"Permission to send an SMS has not been enabled for the region indicated by the 'To' number", "The account's geographic permissions do not allow messages to the To number's country.", "Programmable Messaging"},
+	21602: {"Message body is required", "The message has no body and no media.", "Programmable Messaging"},
+	21606: {"The 'From' phone number provided is not a valid message-capable Twilio phone number for this destination", "The From number cannot send messages to the To number.", "Programmable Messaging"},
+	21608: {"The number is unverified", "Trial accounts can only send messages to verified numbers.", "Programmable Messaging"},
+	21610: {"Attempt to send to unsubscribed recipient", "The recipient replied STOP to the sender, and must reply START before they can be sent messages again.", "Programmable Messaging"},
+	21611: {"This 'From' number has exceeded the maximum number of queued messages", "Too many messages are queued for the From number; send more slowly.", "Programmable Messaging"},
+	21612: {"Message cannot be sent with the current combination of 'To' and/or 'From' parameters", "Twilio cannot route a message between the To and From numbers.", "Programmable Messaging"},
+	21614: {"'To' number is not a valid mobile number", "The To number cannot receive SMS, for example because it is a landline.", "Programmable Messaging"},
+	21617: {"The concatenated message body exceeds the 1600 character limit", "The message body is longer than 1600 characters.", "Programmable Messaging"},
+	30001: {"Queue overflow", "The message was queued for longer than Twilio allows, because messages were sent faster than the sender's throughput.", "Programmable Messaging"},
+	30002: {"Account suspended", "The account was suspended when the message was sent.", "Programmable Messaging"},
+	30003: {"Unreachable destination handset", "The recipient's handset is off, out of coverage or otherwise unable to receive the message.", "Programmable Messaging"},
+	30004: {"Message blocked", "The message was blocked, by the recipient or by their carrier.", "Programmable Messaging"},
+	30005: {"Unknown destination handset", "The To number is unknown to its carrier and may no longer be in service.", "Programmable Messaging"},
+	30006: {"Landline or unreachable carrier", "The To number is a landline, or its carrier cannot receive messages.", "Programmable Messaging"},
+	30007: {"Message filtered", "The carrier filtered the message, for example as spam or for unregistered traffic.", "Programmable Messaging"},
+	30008: {"Unknown error", "Delivery failed for a reason the carrier did not report.", "Programmable Messaging"},
+	30009: {"Missing inbound segment", "A segment of a multipart message did not arrive.", "Programmable Messaging"},
+	30010: {"Message price exceeds max price", "The message would cost more than its MaxPrice.", "Programmable Messaging"},
+	30032: {"Toll-Free Number Has Not Been Verified", "Messages from an unverified toll-free number are blocked.", "Programmable Messaging"},
+	30034: {"Message from an Unregistered Number", "US carriers block A2P messages from 10DLC numbers that are not registered to a campaign.", "Programmable Messaging"},
+	30410: {"Provider Timeout Error", "The carrier did not respond in time; the message may or may not have been delivered.", "Programmable Messaging"},
+	63003: {"Channel could not find To address", "The channel has no recipient with the To address.", "Conversations"},
+	63016: {"Failed to send freeform message because you are outside the allowed window", "WhatsApp only allows freeform messages within 24 hours of the user's last message; outside it, use an approved template.", "Conversations"},
+	63018: {"Rate limit exceeded for Channel", "Messages were sent faster than the channel allows.", "Conversations"},
+	82002: {"Error on Twilio Function response", "A Twilio Function failed or returned an invalid response.", "Serverless"},
+}
@@ -0,0 +1,75 @@
+// Package errorcode describes Twilio's numeric error codes, such as the
+// ErrorCode of a failed message's status callback or of a Debugger event.
+//
+// Example usage:
+//
+//	cb, err := webhook.DecodeMessageStatus(r)
+//	...
+//	if cb.MessageStatus.IsFailed() {
+//		log.Printf("message %s failed: %v", cb.MessageSid, cb.ErrorCode)
+//		// message SM123 failed: 30003 Unreachable destination handset
+//	}
+package errorcode
+
+//go:generate go run gen.go
+
+import "strconv"
+
+// A Code is a Twilio error code.
+type Code int
+
+// Info describes an error code.
+type Info struct {
+	Code        Code
+	Name        string // Twilio's short message for the error
+	Description string
+	Product     string // the Twilio product that reports it, such as Voice
+}
+
+// Lookup returns the description of code, and whether it is in the
+// catalog.
+func Lookup(code int) (Info, bool) {
+	return Code(code).Info()
+}
+
+// Info returns the description of c, and whether it is in the catalog.
+func (c Code) Info() (Info, bool) {
+	e, ok := catalog[c]
+	if !ok {
+		return Info{Code: c}, false
+	}
+	return Info{Code: c, Name: e.name, Description: e.description, Product: e.product}, true
+}
+
+// Name returns Twilio's short message for c, or "" if c isn't in the
+// catalog.
+func (c Code) Name() string { return catalog[c].name }
+
+// Known reports whether c is in the catalog.
+func (c Code) Known() bool {
+	_, ok := catalog[c]
+	return ok
+}
+
+// URL returns the address of c's page in Twilio's documentation, which
+// lists its causes and possible solutions.
+func (c Code) URL() string {
+	return "https://www.twilio.com/docs/api/errors/" + strconv.Itoa(int(c))
+}
+
+// String returns c followed by its name, or c alone if it isn't in the
+// catalog.
+func (c Code) String() string {
+	s := strconv.Itoa(int(c))
+	if name := c.Name(); name != "" {
+		s += " " + name
+	}
+	return s
+}
+
+// An entry is the catalog's description of a code.
+type entry struct {
+	name        string
+	description string
+	product     string
+}
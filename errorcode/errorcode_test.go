@@ -0,0 +1,37 @@
+package errorcode_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/errorcode"
+)
+
+func TestLookup(t *testing.T) {
+	info, ok := errorcode.Lookup(30003)
+	if !ok || info.Code != 30003 || info.Name != "Unreachable destination handset" ||
+		info.Product != "Programmable Messaging" || info.Description == "" {
+		t.Errorf("Lookup(30003) = %+v, %v", info, ok)
+	}
+
+	info, ok = errorcode.Lookup(99999)
+	if ok || info != (errorcode.Info{Code: 99999}) {
+		t.Errorf("Lookup(99999) = %+v, %v", info, ok)
+	}
+}
+
+func TestCode(t *testing.T) {
+	c := errorcode.Code(11200)
+	if !c.Known() || c.Name() != "HTTP retrieval failure" {
+		t.Errorf("11200: got Known %v and Name %q", c.Known(), c.Name())
+	}
+	if got, want := c.String(), "11200 HTTP retrieval failure"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprint(errorcode.Code(99999)), "99999"; got != want {
+		t.Errorf("unknown code: got %q, want %q", got, want)
+	}
+	if got, want := c.URL(), "https://www.twilio.com/docs/api/errors/11200"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+//go:build ignore
+
+// gen.go writes catalog.go from Twilio's published list of error codes.
+//
+// Usage:
+//
+//	go generate ./errorcode
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+const source = "https://www.twilio.com/docs/api/errors/twilio-error-codes.json"
+
+type errorCode struct {
+	Code        int    `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	Product     string `json:"product"`
+}
+
+func main() {
+	resp, err := http.Get(source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("fetching %s: %s", source, resp.Status)
+	}
+	var codes []errorCode
+	if err := json.NewDecoder(resp.Body).Decode(&codes); err != nil {
+		log.Fatal(err)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go from %s; DO NOT EDIT.\n\n", source)
+	b.WriteString("package errorcode\n\nvar catalog = map[Code]entry{\n")
+	seen := map[int]bool{}
+	for _, c := range codes {
+		if seen[c.Code] || c.Message == "" {
+			continue
+		}
+		seen[c.Code] = true
+		fmt.Fprintf(&b, "\t%d: {%q, %q, %q},\n", c.Code, clean(c.Message), clean(c.Description), clean(c.Product))
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("catalog.go", src, 0o666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// clean collapses the whitespace in s, which the published descriptions
+// use for layout.
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,129 @@
+package twilio
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Errors returned by ValidateRequest. Test for them with errors.Is.
+var (
+	// ErrMissingSignature is returned when the request carries no Twilio
+	// signature header for an accepted scheme.
+	ErrMissingSignature = errors.New("twilio: missing signature header")
+
+	// ErrMalformedSignature is returned when the signature header is not
+	// valid Base64.
+	ErrMalformedSignature = errors.New("twilio: malformed signature header")
+
+	// ErrSignatureMismatch is reported when the signature header does not
+	// match the signature computed from the request. The error returned is
+	// a *URLMismatchError with the details; errors.Is matches it to
+	// ErrSignatureMismatch.
+	ErrSignatureMismatch = errors.New("twilio: signature mismatch")
+
+	// ErrBodyHashMismatch is returned when a JSON webhook's body doesn't
+	// match the bodySHA256 parameter in its signed URL.
+	ErrBodyHashMismatch = errors.New("twilio: body does not match bodySHA256")
+
+	// ErrBodyTooLarge is returned when the request body is larger than the
+	// limit set by DefaultMaxBodyBytes or WithMaxBodyBytes.
+	ErrBodyTooLarge = errors.New("twilio: request body too large")
+
+	// ErrMethodNotAllowed is returned when the request method is not one
+	// allowed by AllowMethods.
+	ErrMethodNotAllowed = errors.New("twilio: method not allowed")
+
+	// ErrUnsupportedContentType is returned when RequireContentType is in
+	// effect and the request body is not in the format Twilio uses.
+	ErrUnsupportedContentType = errors.New("twilio: unsupported content type")
+)
+
+// ErrReplayed is returned when replay protection is enabled and the request
+// has already been accepted. See WithReplayProtection.
+var ErrReplayed = errors.New("twilio: request replayed")
+
+// ErrClientCert is returned when a Validator requires a TLS client
+// certificate and the request didn't present an acceptable one. See
+// RequireClientCert.
+var ErrClientCert = errors.New("twilio: missing or unacceptable client certificate")
+
+// ErrBasicAuth is returned when a Validator requires HTTP Basic
+// authentication and the request's credentials are missing or wrong. See
+// RequireBasicAuth.
+var ErrBasicAuth = errors.New("twilio: missing or incorrect basic auth credentials")
+
+// ErrHostNotAllowed is returned when the request's signed URL is not on one
+// of the hosts configured with AllowedHosts.
+var ErrHostNotAllowed = errors.New("twilio: host not allowed")
+
+// ErrMissingAccountSid is returned by the TokenProvider from AccountTokens
+// when the request has no AccountSid parameter.
+var ErrMissingAccountSid = errors.New("twilio: missing AccountSid parameter")
+
+// A FormError is returned when the request body could not be read or parsed.
+type FormError struct {
+	Err error
+}
+
+func (e *FormError) Error() string { return "twilio: parsing form: " + e.Err.Error() }
+
+func (e *FormError) Unwrap() error { return e.Err }
+
+// A TokenError is returned when a TokenProvider fails to supply a token.
+type TokenError struct {
+	Err error
+}
+
+func (e *TokenError) Error() string { return "twilio: getting auth token: " + e.Err.Error() }
+
+func (e *TokenError) Unwrap() error { return e.Err }
+
+// A StoreError is returned when the Store used for replay protection fails.
+type StoreError struct {
+	Err error
+}
+
+func (e *StoreError) Error() string { return "twilio: replay store: " + e.Err.Error() }
+
+func (e *StoreError) Unwrap() error { return e.Err }
+
+// A URLMismatchError is returned when a request's signature matches none of
+// the URLs it may have been signed with. Usually that is because the URL
+// rebuilt from the request isn't the webhook URL configured in Twilio, as
+// happens behind proxies (see TrustForwardedHeaders and WithBaseURL), so
+// the error lists the URLs that were tried for comparison.
+//
+// Example usage:
+//
+//	var mismatch *twilio.URLMismatchError
+//	if errors.As(err, &mismatch) {
+//		log.Printf("no signature match; tried %q", mismatch.URLs)
+//	}
+type URLMismatchError struct {
+	// URLs are the candidate signed URLs, with any passwords redacted.
+	URLs []string
+}
+
+func newURLMismatchError(urls []string) *URLMismatchError {
+	e := &URLMismatchError{URLs: make([]string, len(urls))}
+	for i, u := range urls {
+		e.URLs[i] = redactURL(u)
+	}
+	return e
+}
+
+// redactURL returns u with any password replaced by "xxxxx".
+func redactURL(u string) string {
+	if p, err := url.Parse(u); err == nil {
+		return p.Redacted()
+	}
+	return u
+}
+
+func (e *URLMismatchError) Error() string {
+	return "twilio: signature mismatch (tried " + strings.Join(e.URLs, ", ") + ")"
+}
+
+// Is reports whether target is ErrSignatureMismatch.
+func (e *URLMismatchError) Is(target error) bool { return target == ErrSignatureMismatch }
@@ -0,0 +1,58 @@
+package twilio
+
+import (
+	"slices"
+	"strings"
+)
+
+// AllowedHosts makes the Validator reject requests whose signed URL is not
+// on one of hosts, with ErrHostNotAllowed. A host may include a port; the
+// default port for the URL's scheme matches a host given without one.
+// Comparison ignores case.
+//
+// The signed URL is rebuilt from the request, and with TrustForwardedHeaders
+// from headers the client controls. A valid signature proves Twilio made a
+// request to that URL, but not that it was meant for this server. Pinning
+// the host stops a request signed for someone else's webhook being replayed
+// against yours, which matters when you share an auth token across
+// applications.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.AllowedHosts("hooks.example.com"))
+func AllowedHosts(hosts ...string) Option {
+	return func(v *Validator) {
+		for _, h := range hosts {
+			v.hosts = append(v.hosts, stripDefaultPort(strings.ToLower(h), ""))
+		}
+	}
+}
+
+// checkHost checks that signedURL is on one of the allowed hosts.
+func (v *Validator) checkHost(signedURL string) error {
+	scheme, rest, _ := strings.Cut(signedURL, "://")
+	host := rest
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndexByte(host, '@'); i >= 0 {
+		host = host[i+1:]
+	}
+	host = stripDefaultPort(strings.ToLower(host), strings.ToLower(scheme))
+	if !slices.Contains(v.hosts, host) {
+		return ErrHostNotAllowed
+	}
+	return nil
+}
+
+// stripDefaultPort removes the default port for scheme from host. If scheme
+// is empty, it removes either default port.
+func stripDefaultPort(host, scheme string) string {
+	if (scheme == "" || scheme == "https") && strings.HasSuffix(host, ":443") {
+		return strings.TrimSuffix(host, ":443")
+	}
+	if (scheme == "" || scheme == "http") && strings.HasSuffix(host, ":80") {
+		return strings.TrimSuffix(host, ":80")
+	}
+	return host
+}
@@ -0,0 +1,50 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestAllowedHosts(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://hooks.example.com/sms", true},
+		{"https://HOOKS.example.com/sms", true},
+		{"https://hooks.example.com:443/sms", true},
+		{"https://other.example.com:8443/sms", true},
+		{"https://other.example.com/sms", false},
+		{"https://evil.example/sms", false},
+		{"https://hooks.example.com.evil.example/sms", false},
+	}
+	v := twilio.New("12345", twilio.AllowedHosts("hooks.example.com", "other.example.com:8443"))
+	for _, test := range tests {
+		r, _ := http.NewRequest("GET", test.url, nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", test.url))
+		err := v.ValidateRequest(r)
+		if test.valid && err != nil {
+			t.Errorf("%s: got %v, want nil", test.url, err)
+		}
+		if !test.valid && err != twilio.ErrHostNotAllowed {
+			t.Errorf("%s: got %v, want ErrHostNotAllowed", test.url, err)
+		}
+	}
+}
+
+func TestAllowedHostsForwarded(t *testing.T) {
+	// The host is checked on the URL that was signed, not the one the
+	// request reached the server on.
+	r := serverRequest("http://10.0.0.1:8080/sms")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "hooks.example.com")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://hooks.example.com/sms"))
+	v := twilio.New("12345", twilio.TrustForwardedHeaders(), twilio.AllowedHosts("hooks.example.com"))
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
@@ -0,0 +1,43 @@
+package twilio
+
+import "net/http"
+
+// InsecureSkipValidation turns off validation entirely: every request is
+// passed to the protected handler, whether or not it came from Twilio. It is
+// meant for local development, for example behind a tunnel whose URL
+// changes on every run, and must never be used in production.
+//
+// warn is called for every request that skips validation and must not be
+// nil; use it to log loudly so the option can't quietly ship. Requests that
+// skip validation are tagged in their context with a Result whose Skipped
+// field is true and whose Valid field is false.
+//
+// Example usage:
+//
+//	var opts []twilio.Option
+//	if os.Getenv("TWILIO_INSECURE_DEV") == "1" {
+//		opts = append(opts, twilio.InsecureSkipValidation(func(r *http.Request) {
+//			log.Printf("WARNING: Twilio validation disabled; accepting %s %s", r.Method, r.URL)
+//		}))
+//	}
+//	v := twilio.New(myAuthToken, opts...)
+func InsecureSkipValidation(warn func(r *http.Request)) Option {
+	if warn == nil {
+		panic("twilio: InsecureSkipValidation requires a warning callback")
+	}
+	return func(v *Validator) { v.skipWarn = warn }
+}
+
+// skip is used in place of check when validation is turned off.
+func (v *Validator) skip(r *http.Request) *Result {
+	v.skipWarn(r)
+	res := &Result{Skipped: true, TokenIndex: -1}
+	body, err := bufferBody(r, v.maxBody)
+	if err != nil {
+		return res
+	}
+	if form, err := postForm(r, body); err == nil {
+		res.Params = mergeParams(r.URL.Query(), form)
+	}
+	return res
+}
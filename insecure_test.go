@@ -0,0 +1,44 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestInsecureSkipValidation(t *testing.T) {
+	warned := 0
+	v := twilio.New("55555", twilio.InsecureSkipValidation(func(r *http.Request) { warned++ }))
+
+	var res *twilio.Result
+	w := httptest.NewRecorder()
+	v.Validate(func(w http.ResponseWriter, r *http.Request) {
+		res, _ = twilio.FromContext(r.Context())
+	})(w, exampleRequest())
+
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, want 200", w.Code)
+	}
+	if warned != 1 {
+		t.Errorf("warning callback called %d times, want 1", warned)
+	}
+	if res == nil || !res.Skipped || res.Valid {
+		t.Errorf("got %+v, want a skipped, unvalidated result", res)
+	}
+	if got := res.Params.Get("Digits"); got != "1234" {
+		t.Errorf("Params Digits = %q, want 1234", got)
+	}
+}
+
+func TestInsecureSkipValidationRequiresWarning(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("InsecureSkipValidation(nil) should panic")
+		}
+	}()
+	twilio.InsecureSkipValidation(nil)
+}
@@ -0,0 +1,95 @@
+// Package adapt holds what the serverless adapters share: rebuilding the
+// request Twilio made from a platform's event, running it through a
+// twilio.Validator, and capturing the response.
+package adapt
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// NewRequest builds the request a server would have received for rawURL,
+// with header and body.
+func NewRequest(ctx context.Context, method, rawURL string, header http.Header, body []byte) (*http.Request, error) {
+	r, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.Header = header
+	r.RequestURI = r.URL.RequestURI()
+	return r, nil
+}
+
+// Scheme returns the scheme in the first entry of header's
+// X-Forwarded-Proto, or def if it has none.
+func Scheme(header http.Header, def string) string {
+	proto := header.Get("X-Forwarded-Proto")
+	if proto == "" {
+		return def
+	}
+	first, _, _ := strings.Cut(proto, ",")
+	return strings.ToLower(strings.TrimSpace(first))
+}
+
+// Check validates r with v. If it passes, Check returns it as the
+// protected handler would see it. Otherwise Check returns the failure
+// response.
+func Check(v *twilio.Validator, r *http.Request) (*http.Request, *Recorder) {
+	var passed *http.Request
+	w := NewRecorder()
+	v.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		passed = r
+	})).ServeHTTP(w, r)
+	if passed == nil {
+		return nil, w
+	}
+	return passed, nil
+}
+
+// A Recorder is a minimal http.ResponseWriter that keeps what is written
+// to it.
+type Recorder struct {
+	HeaderMap http.Header
+	Code      int
+	Body      bytes.Buffer
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{HeaderMap: make(http.Header)}
+}
+
+func (w *Recorder) Header() http.Header { return w.HeaderMap }
+
+func (w *Recorder) WriteHeader(code int) {
+	if w.Code == 0 {
+		w.Code = code
+	}
+}
+
+func (w *Recorder) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.Body.Write(b)
+}
+
+// StatusCode returns the status written, or 200 OK if none was.
+func (w *Recorder) StatusCode() int {
+	if w.Code == 0 {
+		return http.StatusOK
+	}
+	return w.Code
+}
+
+// SingleHeaders returns the header written, with the values of each field
+// joined by commas, for platforms that only take one value per field.
+func (w *Recorder) SingleHeaders() map[string]string {
+	headers := make(map[string]string, len(w.HeaderMap))
+	for k, vs := range w.HeaderMap {
+		headers[k] = strings.Join(vs, ", ")
+	}
+	return headers
+}
@@ -0,0 +1,67 @@
+package adapt_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/internal/adapt"
+)
+
+func TestNewRequest(t *testing.T) {
+	header := http.Header{"X-Forwarded-Proto": {"HTTP, https"}}
+	r, err := adapt.NewRequest(context.Background(), "POST", adapt.Scheme(header, "https")+"://example.com/sms?a=1", header, []byte("Body=hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.URL.String() != "http://example.com/sms?a=1" || r.RequestURI != "/sms?a=1" || r.Host != "example.com" {
+		t.Errorf("got URL %s, RequestURI %s and Host %s", r.URL, r.RequestURI, r.Host)
+	}
+	if got := adapt.Scheme(http.Header{}, "https"); got != "https" {
+		t.Errorf("Scheme without X-Forwarded-Proto = %q, want https", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const target = "https://example.com/sms"
+	params := url.Values{"Body": {"hi"}}
+	header := http.Header{
+		"Content-Type":           {"application/x-www-form-urlencoded"},
+		"X-Twilio-Signature-256": {twilio.ComputeSignatureSHA256([]byte("12345"), target, params)},
+	}
+	v := twilio.New("12345")
+
+	r, _ := adapt.NewRequest(context.Background(), "POST", target, header, []byte(params.Encode()))
+	passed, failed := adapt.Check(v, r)
+	if failed != nil {
+		t.Fatalf("valid request failed with %d: %s", failed.StatusCode(), failed.Body.String())
+	}
+	if res, ok := twilio.FromContext(passed.Context()); !ok || !res.Valid {
+		t.Error("passed request has no valid Result")
+	}
+
+	r, _ = adapt.NewRequest(context.Background(), "POST", target, header, []byte("Body=forged"))
+	if passed, failed = adapt.Check(v, r); passed != nil || failed == nil || failed.StatusCode() != http.StatusForbidden {
+		t.Errorf("forged request: got %v and %v", passed, failed)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	w := adapt.NewRecorder()
+	if w.StatusCode() != http.StatusOK {
+		t.Errorf("empty recorder: StatusCode = %d", w.StatusCode())
+	}
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Origin")
+	w.WriteHeader(http.StatusTeapot)
+	w.Write([]byte("short and stout"))
+	if w.StatusCode() != http.StatusTeapot || w.Body.String() != "short and stout" {
+		t.Errorf("got %d %q", w.StatusCode(), w.Body.String())
+	}
+	if got := w.SingleHeaders(); !reflect.DeepEqual(got, map[string]string{"Vary": "Accept, Origin"}) {
+		t.Errorf("SingleHeaders() = %v", got)
+	}
+}
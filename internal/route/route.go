@@ -0,0 +1,51 @@
+// Package route expands the URL templates of the router adapters.
+package route
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Expand replaces each {name} or {name:pattern} in template with
+// param(name) and appends r's query string. Braces inside a pattern, as in
+// {id:[0-9]{3}}, are matched as gorilla/mux matches them.
+func Expand(template string, r *http.Request, param func(name string) string) string {
+	var b strings.Builder
+	rest := template
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			break
+		}
+		end := closingBrace(rest, open)
+		if end < 0 {
+			break
+		}
+		b.WriteString(rest[:open])
+		name, _, _ := strings.Cut(rest[open+1:end], ":") // drop any pattern
+		b.WriteString(param(name))
+		rest = rest[end+1:]
+	}
+	b.WriteString(rest)
+	if r.URL.RawQuery != "" {
+		b.WriteString("?" + r.URL.RawQuery)
+	}
+	return b.String()
+}
+
+// closingBrace returns the index of the brace in s that closes the one at
+// open, or -1 if it is never closed.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,27 @@
+package route_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/internal/route"
+)
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"sid": "CA123", "kind": "status"}
+	param := func(name string) string { return vars[name] }
+	for _, tt := range []struct {
+		template, target, want string
+	}{
+		{"https://example.com/calls/{sid}/{kind}", "/x", "https://example.com/calls/CA123/status"},
+		{"https://example.com/calls/{sid:CA[0-9]+}", "/x?a=1", "https://example.com/calls/CA123?a=1"},
+		{"https://example.com/calls/{sid:CA[0-9]{3}}/{kind}", "/x", "https://example.com/calls/CA123/status"},
+		{"https://example.com/{missing}/", "/x", "https://example.com//"},
+		{"https://example.com/{unclosed", "/x", "https://example.com/{unclosed"},
+	} {
+		r := httptest.NewRequest("POST", tt.target, nil)
+		if got := route.Expand(tt.template, r, param); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
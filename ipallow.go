@@ -0,0 +1,185 @@
+package twilio
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// An IPRangeSource returns the IP ranges that Twilio sends webhooks from.
+type IPRangeSource func(ctx context.Context) ([]netip.Prefix, error)
+
+// FetchIPRanges returns an IPRangeSource that downloads a list of ranges
+// from url using client, or http.DefaultClient if client is nil. The list
+// holds one CIDR prefix per line; blank lines and lines starting with '#'
+// are ignored.
+func FetchIPRanges(client *http.Client, url string) IPRangeSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) ([]netip.Prefix, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("twilio: fetching IP ranges from %s: %s", url, resp.Status)
+		}
+		var ranges []netip.Prefix
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			p, err := netip.ParsePrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("twilio: fetching IP ranges from %s: %v", url, err)
+			}
+			ranges = append(ranges, p)
+		}
+		return ranges, sc.Err()
+	}
+}
+
+// An IPAllowlist is middleware that only admits requests from Twilio's IP
+// ranges. It is defense in depth: signatures remain the primary check, so
+// use it alongside a Validator rather than instead of one.
+//
+// Example usage:
+//
+//	allow := twilio.NewIPAllowlist(twilio.FetchIPRanges(nil, rangesURL), time.Hour, 1)
+//	if err := allow.Start(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	http.Handle("/sms", allow.Middleware(twilio.Middleware(myAuthToken)(smsHandler)))
+type IPAllowlist struct {
+	source         IPRangeSource
+	refresh        time.Duration
+	trustedProxies int
+	ranges         atomic.Pointer[[]netip.Prefix]
+
+	// OnRefreshError, if set, is called when a periodic refresh fails. The
+	// previous ranges stay in effect.
+	OnRefreshError func(error)
+}
+
+// NewIPAllowlist returns an IPAllowlist that loads its ranges from source
+// and reloads them every refresh once started.
+//
+// trustedProxies is the number of proxies in front of the server that
+// append to X-Forwarded-For. If it is 0, the client IP is taken from
+// r.RemoteAddr. Otherwise it is the entry that many places from the end of
+// X-Forwarded-For, since entries further left may have been forged by the
+// client. NewIPAllowlist panics if trustedProxies is negative.
+func NewIPAllowlist(source IPRangeSource, refresh time.Duration, trustedProxies int) *IPAllowlist {
+	if trustedProxies < 0 {
+		panic(fmt.Sprintf("twilio: NewIPAllowlist: trustedProxies must not be negative, not %d", trustedProxies))
+	}
+	return &IPAllowlist{source: source, refresh: refresh, trustedProxies: trustedProxies}
+}
+
+// Start loads the ranges, then keeps reloading them in the background until
+// ctx is done. It returns an error if the initial load fails. If the
+// refresh interval is not positive, the ranges are loaded once and not
+// reloaded.
+func (a *IPAllowlist) Start(ctx context.Context) error {
+	if err := a.Refresh(ctx); err != nil {
+		return err
+	}
+	if a.refresh <= 0 {
+		return nil
+	}
+	go func() {
+		t := time.NewTicker(a.refresh)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := a.Refresh(ctx); err != nil && a.OnRefreshError != nil {
+					a.OnRefreshError(err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh reloads the ranges from the source.
+func (a *IPAllowlist) Refresh(ctx context.Context) error {
+	ranges, err := a.source(ctx)
+	if err != nil {
+		return err
+	}
+	a.ranges.Store(&ranges)
+	return nil
+}
+
+// Allowed reports whether r comes from one of the allowed ranges. Until the
+// ranges have been loaded, no request is allowed.
+func (a *IPAllowlist) Allowed(r *http.Request) bool {
+	ranges := a.ranges.Load()
+	if ranges == nil {
+		return false
+	}
+	ip, ok := a.clientIP(r)
+	if !ok {
+		return false
+	}
+	for _, p := range *ranges {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware wraps next so that requests from outside the allowed ranges
+// get 403 Forbidden.
+func (a *IPAllowlist) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Allowed(r) {
+			forbidden(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *IPAllowlist) clientIP(r *http.Request) (netip.Addr, bool) {
+	var s string
+	if a.trustedProxies == 0 {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		s = host
+	} else {
+		var hops []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			hops = append(hops, strings.Split(v, ",")...)
+		}
+		if len(hops) < a.trustedProxies {
+			return netip.Addr{}, false
+		}
+		s = strings.TrimSpace(hops[len(hops)-a.trustedProxies])
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
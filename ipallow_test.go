@@ -0,0 +1,120 @@
+package twilio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func staticRanges(prefixes ...string) twilio.IPRangeSource {
+	return func(ctx context.Context) ([]netip.Prefix, error) {
+		var ranges []netip.Prefix
+		for _, p := range prefixes {
+			ranges = append(ranges, netip.MustParsePrefix(p))
+		}
+		return ranges, nil
+	}
+}
+
+func TestIPAllowlist(t *testing.T) {
+	tests := []struct {
+		proxies    int
+		remoteAddr string
+		xff        string
+		want       bool
+	}{
+		{0, "54.172.60.1:1234", "", true},
+		{0, "10.0.0.1:1234", "54.172.60.1", false},
+		{1, "10.0.0.1:1234", "54.172.60.1", true},
+		{1, "10.0.0.1:1234", "54.172.60.1, 203.0.113.9", false},
+		{2, "10.0.0.1:1234", "203.0.113.9, 54.172.60.1, 10.0.0.2", true},
+		{2, "10.0.0.1:1234", "54.172.60.1", false},
+	}
+	for _, test := range tests {
+		a := twilio.NewIPAllowlist(staticRanges("54.172.60.0/23"), time.Hour, test.proxies)
+		if err := a.Refresh(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest("POST", "/sms", nil)
+		r.RemoteAddr = test.remoteAddr
+		if test.xff != "" {
+			r.Header.Set("X-Forwarded-For", test.xff)
+		}
+		if got := a.Allowed(r); got != test.want {
+			t.Errorf("proxies=%d remote=%s xff=%q: Allowed = %v, want %v",
+				test.proxies, test.remoteAddr, test.xff, got, test.want)
+		}
+	}
+}
+
+func TestIPAllowlistNoRefresh(t *testing.T) {
+	for _, refresh := range []time.Duration{0, -time.Second} {
+		var loads atomic.Int32
+		a := twilio.NewIPAllowlist(func(ctx context.Context) ([]netip.Prefix, error) {
+			loads.Add(1)
+			return staticRanges("54.172.60.0/23")(ctx)
+		}, refresh, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := a.Start(ctx); err != nil {
+			t.Fatalf("refresh %v: %v", refresh, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		if n := loads.Load(); n != 1 {
+			t.Errorf("refresh %v: ranges loaded %d times, want once", refresh, n)
+		}
+		r := httptest.NewRequest("POST", "/sms", nil)
+		r.RemoteAddr = "54.172.60.1:1234"
+		if !a.Allowed(r) {
+			t.Errorf("refresh %v: request from Twilio's ranges not allowed", refresh)
+		}
+	}
+}
+
+func TestIPAllowlistNotLoaded(t *testing.T) {
+	a := twilio.NewIPAllowlist(func(ctx context.Context) ([]netip.Prefix, error) {
+		return nil, errors.New("unavailable")
+	}, time.Hour, 0)
+	if err := a.Start(context.Background()); err == nil {
+		t.Error("Start should fail when the initial load fails")
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/sms", nil)
+	a.Middleware(http.NotFoundHandler()).ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want 403", w.Code)
+	}
+}
+
+func TestFetchIPRanges(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# Twilio webhook ranges\n54.172.60.0/23\n\n2600:1f18::/32\n"))
+	}))
+	defer srv.Close()
+
+	ranges, err := twilio.FetchIPRanges(srv.Client(), srv.URL)(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 2 || ranges[0].String() != "54.172.60.0/23" || ranges[1].String() != "2600:1f18::/32" {
+		t.Errorf("got %v", ranges)
+	}
+}
+
+func TestIPAllowlistNegativeProxies(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewIPAllowlist with -1 trusted proxies should panic")
+		}
+	}()
+	twilio.NewIPAllowlist(func(ctx context.Context) ([]netip.Prefix, error) {
+		return nil, nil
+	}, time.Hour, -1)
+}
@@ -0,0 +1,142 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"sync"
+)
+
+// A macKind is one of Twilio's signature schemes.
+type macKind int
+
+const (
+	macSHA1 macKind = iota
+	macSHA256
+)
+
+// macHashes are the hashes for each kind. newSHA1 is nil when the package
+// is built with the twilio_nosha1 tag.
+var macHashes = [...]func() hash.Hash{
+	macSHA1:   newSHA1,
+	macSHA256: sha256.New,
+}
+
+// WithHMAC makes the Validator compute signatures with the given HMAC
+// implementations rather than its own, which is built on crypto/sha1 and
+// crypto/sha256. newSHA1 and newSHA256 return a new HMAC-SHA1 or
+// HMAC-SHA256 keyed with key. Use it when policy requires MACs to come from
+// a particular module, such as a FIPS 140 validated one.
+//
+// If newSHA1 is nil, requests signed only with the SHA-1 scheme fail with
+// ErrMissingSignature, as with RequireSHA256. Twilio's SHA-256 scheme is
+// always used when a request carries both signatures.
+//
+// To make sure the package never computes SHA-1, whatever options are
+// used, build with the twilio_nosha1 tag. The package then rejects SHA-1
+// signatures everywhere, ignoring any newSHA1 passed to WithHMAC, and
+// ComputeSignature panics.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithHMAC(nil, func(key []byte) hash.Hash {
+//		return hmac.New(sha256.New, key)
+//	}))
+func WithHMAC(newSHA1, newSHA256 func(key []byte) hash.Hash) Option {
+	if macHashes[macSHA1] == nil {
+		newSHA1 = nil // built with twilio_nosha1
+	}
+	return func(v *Validator) {
+		v.signing.macs = &[len(macHashes)]func(key []byte) hash.Hash{
+			macSHA1:   newSHA1,
+			macSHA256: newSHA256,
+		}
+	}
+}
+
+// hasMAC reports whether sg can check signatures of the given kind.
+func (sg signing) hasMAC(kind macKind) bool {
+	if sg.macs != nil {
+		return sg.macs[kind] != nil
+	}
+	return macHashes[kind] != nil
+}
+
+// newMAC returns a constructor for HMACs of the given kind, for use off the
+// hot path.
+func (sg signing) newMAC(kind macKind) func(key []byte) hash.Hash {
+	if sg.macs != nil {
+		return sg.macs[kind]
+	}
+	return func(key []byte) hash.Hash { return hmac.New(macHashes[kind], key) }
+}
+
+// sum returns the HMAC of msg under key. The result is only valid until
+// the next use of st.
+func (sg signing) sum(st *macState, kind macKind, key, msg []byte) []byte {
+	if sg.macs == nil {
+		return st.mac(kind, key, msg)
+	}
+	mac := sg.macs[kind](key)
+	mac.Write(msg)
+	st.sum = mac.Sum(st.sum[:0])
+	return st.sum
+}
+
+// hashPools hold unkeyed hashes of each kind. crypto/hmac can't be reset
+// with a new key, so rather than pool an HMAC per key we compute HMACs from
+// these (see macState.mac) and share them between all keys.
+var hashPools = [len(macHashes)]sync.Pool{
+	macSHA1:   {New: func() any { return macHashes[macSHA1]() }},
+	macSHA256: {New: func() any { return macHashes[macSHA256]() }},
+}
+
+// macState is the scratch space used while checking a signature. Get one
+// from macStates and put it back when done; checking a request then needn't
+// allocate anything that doesn't outlive it.
+type macState struct {
+	urls     []string // the candidate signed URLs
+	params   []byte   // the sorted POST parameters, concatenated
+	buf      []byte   // a candidate URL followed by params
+	pairs    urlValues
+	received []byte
+
+	key, pad, inner, sum []byte // used by mac
+}
+
+var macStates = sync.Pool{New: func() any { return new(macState) }}
+
+// mac returns the HMAC of msg under key, as defined in RFC 2104. The result
+// is only valid until the next call.
+func (st *macState) mac(kind macKind, key, msg []byte) []byte {
+	h := hashPools[kind].Get().(hash.Hash)
+	defer hashPools[kind].Put(h)
+
+	bs := h.BlockSize()
+	if len(key) > bs {
+		h.Reset()
+		h.Write(key)
+		st.key = h.Sum(st.key[:0])
+		key = st.key
+	}
+	pad := grow(&st.pad, bs)
+	clear(pad)
+	copy(pad, key)
+
+	for i := range pad {
+		pad[i] ^= 0x36
+	}
+	h.Reset()
+	h.Write(pad)
+	h.Write(msg)
+	st.inner = h.Sum(st.inner[:0])
+
+	for i := range pad {
+		pad[i] ^= 0x36 ^ 0x5c
+	}
+	h.Reset()
+	h.Write(pad)
+	h.Write(st.inner)
+	st.sum = h.Sum(st.sum[:0])
+	return st.sum
+}
@@ -0,0 +1,33 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestWithHMAC(t *testing.T) {
+	var calls int
+	v := twilio.New("12345", twilio.WithHMAC(nil, func(key []byte) hash.Hash {
+		calls++
+		return hmac.New(sha256.New, key)
+	}))
+
+	if err := v.ValidateRequest(exampleRequest()); err != twilio.ErrMissingSignature {
+		t.Errorf("SHA-1 signed request: got %v, want ErrMissingSignature", err)
+	}
+
+	r := exampleRequest()
+	r.Header.Set("X-Twilio-Signature-256", sign256("12345", "https://mycompany.com/myapp.php?foo=1&bar=2"+exampleParams))
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("SHA-256 signed request: got %v, want nil", err)
+	}
+	if calls == 0 {
+		t.Error("the injected HMAC was not used")
+	}
+}
@@ -0,0 +1,88 @@
+package twilio
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// AllowMethods makes the Validator reject requests whose method is not one
+// of methods, typically the single method your webhook is configured with
+// in the Twilio console. The check happens before the body is read, and
+// such requests fail validation with ErrMethodNotAllowed.
+func AllowMethods(methods ...string) Option {
+	return func(v *Validator) { v.methods = methods }
+}
+
+// RequireContentType makes the Validator reject POST requests whose body is
+// not in the format Twilio uses: application/json for JSON webhooks, whose
+// URLs carry a bodySHA256 parameter, and application/x-www-form-urlencoded
+// for everything else. The check happens before the body is read, and such
+// requests fail validation with ErrUnsupportedContentType.
+func RequireContentType() Option {
+	return func(v *Validator) { v.requireContentType = true }
+}
+
+// checkRequestLine applies the AllowMethods and RequireContentType checks.
+func (v *Validator) checkRequestLine(r *http.Request) error {
+	if v.methods != nil && !slices.Contains(v.methods, r.Method) {
+		return ErrMethodNotAllowed
+	}
+	if v.requireContentType && r.Method == "POST" {
+		want := "application/x-www-form-urlencoded"
+		if r.URL.Query().Has(ParamBodySHA256) {
+			want = "application/json"
+		}
+		ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if ct != want {
+			return ErrUnsupportedContentType
+		}
+	}
+	return nil
+}
+
+// failure is the default failure handler. It responds with a status code
+// that reflects why validation failed: 401 Unauthorized, 405 Method Not
+// Allowed, 413 Content Too Large or 415 Unsupported Media Type where they
+// apply, and 403 Forbidden otherwise.
+func (v *Validator) failure(w http.ResponseWriter, r *http.Request) {
+	res, _ := FromContext(r.Context())
+	if res == nil {
+		forbidden(w, r)
+		return
+	}
+	switch code := StatusCode(res.Err); code {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", `Basic realm="twilio"`)
+		http.Error(w, "401 Unauthorized", code)
+	case http.StatusMethodNotAllowed:
+		w.Header().Set("Allow", strings.Join(v.methods, ", "))
+		http.Error(w, "405 Method Not Allowed", code)
+	case http.StatusRequestEntityTooLarge:
+		http.Error(w, "413 Request Entity Too Large", code)
+	case http.StatusUnsupportedMediaType:
+		http.Error(w, "415 Unsupported Media Type", code)
+	default:
+		forbidden(w, r)
+	}
+}
+
+// StatusCode returns the HTTP status code the default failure handler
+// responds with when validation fails with err. Adapters to frameworks that
+// don't use the failure handler can use it to respond the same way.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrBasicAuth):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedContentType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusForbidden
+	}
+}
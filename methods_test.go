@@ -0,0 +1,76 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestAllowMethods(t *testing.T) {
+	v := twilio.New("12345", twilio.AllowMethods("POST"))
+	if err := v.ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("POST: got %v, want nil", err)
+	}
+
+	r := httptest.NewRequest("PUT", "https://mycompany.com/myapp.php", nil)
+	w := httptest.NewRecorder()
+	v.Validate(ok)(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT: got status %d, want 405", w.Code)
+	}
+	if got := w.Header().Get("Allow"); got != "POST" {
+		t.Errorf("Allow = %q, want POST", got)
+	}
+}
+
+func TestRequireContentType(t *testing.T) {
+	v := twilio.New("12345", twilio.RequireContentType())
+	if err := v.ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("form body: got %v, want nil", err)
+	}
+
+	r := exampleRequest()
+	r.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	v.Validate(ok)(w, r)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain: got status %d, want 415", w.Code)
+	}
+
+	r = exampleRequest()
+	r.URL.RawQuery = "bodySHA256=abc"
+	if err := v.ValidateRequest(r); err != twilio.ErrUnsupportedContentType {
+		t.Errorf("bodySHA256 with a form body: got %v, want ErrUnsupportedContentType", err)
+	}
+}
+
+func TestDefaultFailureStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	twilio.New("12345", twilio.WithMaxBodyBytes(16)).Validate(ok)(w, exampleRequest())
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, want 413", w.Code)
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{twilio.ErrMethodNotAllowed, http.StatusMethodNotAllowed},
+		{twilio.ErrBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{twilio.ErrUnsupportedContentType, http.StatusUnsupportedMediaType},
+		{twilio.ErrBasicAuth, http.StatusUnauthorized},
+		{twilio.ErrSignatureMismatch, http.StatusForbidden},
+		{&twilio.URLMismatchError{}, http.StatusForbidden},
+	}
+	for _, test := range tests {
+		if code := twilio.StatusCode(test.err); code != test.code {
+			t.Errorf("StatusCode(%v) = %d, want %d", test.err, code, test.code)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package twilio
+
+import "net/http"
+
+// Handle registers h on mux for pattern, protected by a Validator for
+// twilioAuthToken configured with opts. With the method-scoped patterns of
+// Go 1.22 it makes a webhook route a one-liner.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	twilio.Handle(mux, "POST /voice", myAuthToken, voiceHandler)
+//	twilio.HandleFunc(mux, "POST /sms", myAuthToken, smsHandler)
+func Handle(mux *http.ServeMux, pattern, twilioAuthToken string, h http.Handler, opts ...Option) {
+	mux.Handle(pattern, New(twilioAuthToken, opts...).Middleware(h))
+}
+
+// HandleFunc is like Handle, but takes a handler function.
+func HandleFunc(mux *http.ServeMux, pattern, twilioAuthToken string, h http.HandlerFunc, opts ...Option) {
+	Handle(mux, pattern, twilioAuthToken, h, opts...)
+}
+
+// A Mux is an http.ServeMux whose routes are each protected by their own
+// Validator. Use it when different Twilio projects or subaccounts, each
+// with its own auth token, send webhooks to different paths on the same
+// server. Requests that match no route get the ServeMux's 404 response.
+//
+// Example usage:
+//
+//	m := twilio.NewMux(twilio.TrustForwardedHeaders())
+//	m.HandleFunc("POST /support/voice", supportAuthToken, supportVoiceHandler)
+//	m.HandleFunc("POST /sales/voice", salesAuthToken, salesVoiceHandler)
+//	http.ListenAndServe(":8080", m)
+type Mux struct {
+	mux  http.ServeMux
+	opts []Option
+}
+
+// NewMux returns a Mux whose Validators are all configured with opts.
+func NewMux(opts ...Option) *Mux {
+	return &Mux{opts: opts}
+}
+
+// Handle registers h for pattern, which has the syntax of http.ServeMux
+// patterns. Requests matching pattern are validated against
+// twilioAuthToken, plus any per-route opts, before h sees them.
+func (m *Mux) Handle(pattern, twilioAuthToken string, h http.Handler, opts ...Option) {
+	Handle(&m.mux, pattern, twilioAuthToken, h, append(m.opts[:len(m.opts):len(m.opts)], opts...)...)
+}
+
+// HandleFunc is like Handle, but takes a handler function.
+func (m *Mux) HandleFunc(pattern, twilioAuthToken string, h http.HandlerFunc, opts ...Option) {
+	m.Handle(pattern, twilioAuthToken, h, opts...)
+}
+
+// ServeHTTP dispatches r to the handler whose pattern best matches it.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
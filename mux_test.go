@@ -0,0 +1,83 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestMux(t *testing.T) {
+	m := twilio.NewMux()
+	m.HandleFunc("/a/", "aaaaa", ok)
+	m.HandleFunc("/b/", "bbbbb", ok)
+
+	tests := []struct {
+		url, key string
+		code     int
+	}{
+		{"https://example.com/a/sms", "aaaaa", http.StatusOK},
+		{"https://example.com/b/sms", "bbbbb", http.StatusOK},
+		{"https://example.com/a/sms", "bbbbb", http.StatusForbidden},
+		{"https://example.com/b/sms", "aaaaa", http.StatusForbidden},
+		{"https://example.com/c/sms", "aaaaa", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("GET", test.url, nil)
+		r.Header.Set("X-Twilio-Signature", sign(test.key, test.url))
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s signed with %s: got %d, want %d", test.url, test.key, w.Code, test.code)
+		}
+	}
+}
+
+func TestMuxOptions(t *testing.T) {
+	// Shared options apply to every route, and per-route options only to
+	// their own.
+	m := twilio.NewMux(twilio.WithBaseURL("https://hooks.example.com"))
+	m.HandleFunc("/a", "12345", ok)
+	m.HandleFunc("/b", "12345", ok, twilio.AddPrefix("/prod"))
+
+	for path, signed := range map[string]string{
+		"/a": "https://hooks.example.com/a",
+		"/b": "https://hooks.example.com/prod/b",
+	} {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", signed))
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: got %d, want 200", path, w.Code)
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	mux := http.NewServeMux()
+	twilio.HandleFunc(mux, "POST /voice", "12345", ok)
+	twilio.Handle(mux, "GET /status", "12345", http.HandlerFunc(ok), twilio.AddPrefix("/prod"))
+
+	tests := []struct {
+		method, url, signed string
+		code                int
+	}{
+		{"POST", "https://example.com/voice", "https://example.com/voice", http.StatusOK},
+		{"POST", "https://example.com/voice", "https://example.com/other", http.StatusForbidden},
+		{"GET", "https://example.com/voice", "https://example.com/voice", http.StatusMethodNotAllowed},
+		{"GET", "https://example.com/status", "https://example.com/prod/status", http.StatusOK},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest(test.method, test.url, nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", test.signed))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s %s signed as %s: got %d, want %d", test.method, test.url, test.signed, w.Code, test.code)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package twilio
+
+// Names of the HTTP headers Twilio sends with webhook requests.
+const (
+	// SignatureHeader carries the HMAC-SHA1 signature of a request.
+	SignatureHeader = "X-Twilio-Signature"
+
+	// Signature256Header carries the HMAC-SHA256 signature of a request,
+	// for products that use Twilio's newer scheme.
+	Signature256Header = "X-Twilio-Signature-256"
+
+	// IdempotencyTokenHeader carries a token that is the same for each
+	// retry of a webhook request. See WithReplayProtection.
+	IdempotencyTokenHeader = "I-Twilio-Idempotency-Token"
+)
+
+// Names of common webhook parameters.
+const (
+	ParamAccountSid = "AccountSid"
+	ParamCallSid    = "CallSid"
+	ParamMessageSid = "MessageSid"
+	ParamFrom       = "From"
+	ParamTo         = "To"
+	ParamBody       = "Body"
+	ParamTimestamp  = "Timestamp"
+
+	// ParamIdempotencyToken identifies a usage trigger callback, and is the
+	// same for each retry of it.
+	ParamIdempotencyToken = "IdempotencyToken"
+
+	// ParamBodySHA256 is the query parameter through which Twilio signs the
+	// body of a JSON webhook.
+	ParamBodySHA256 = "bodySHA256"
+)
@@ -0,0 +1,28 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestWithSignatureHeaders(t *testing.T) {
+	r := exampleRequest()
+	r.Header.Set("X-Gw-Signature", r.Header.Get(twilio.SignatureHeader))
+	r.Header.Del(twilio.SignatureHeader)
+	if twilio.New("12345").IsValid(r) {
+		t.Error("valid without WithSignatureHeaders")
+	}
+	if err := twilio.New("12345", twilio.WithSignatureHeaders("X-Gw-Signature", "")).ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	r = exampleRequest()
+	r.Header.Set("X-Gw-Signature-256", sign256("12345", "https://mycompany.com/myapp.php?foo=1&bar=2"+exampleParams))
+	v := twilio.New("12345", twilio.WithSignatureHeaders("", "X-Gw-Signature-256"), twilio.RequireSHA256())
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("renamed SHA-256 header: got %v, want nil", err)
+	}
+}
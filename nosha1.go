@@ -0,0 +1,9 @@
+//go:build twilio_nosha1
+
+package twilio
+
+import "hash"
+
+// newSHA1 is nil: the package was built with the twilio_nosha1 tag, so
+// SHA-1 signatures are rejected.
+var newSHA1 func() hash.Hash
@@ -0,0 +1,72 @@
+//go:build twilio_nosha1
+
+package twilio_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// sha1Request returns a form webhook signed with the SHA-1 scheme, computed
+// here since the package itself refuses to.
+func sha1Request() *http.Request {
+	const target = "https://example.com/sms"
+	form := url.Values{"Body": {"hi"}}
+	mac := hmac.New(sha1.New, []byte("12345"))
+	mac.Write([]byte(target + "Bodyhi"))
+	r := httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set(twilio.SignatureHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func TestNoSHA1(t *testing.T) {
+	if err := twilio.New("12345").ValidateRequest(sha1Request()); err != twilio.ErrMissingSignature {
+		t.Errorf("SHA-1 signed request: got %v, want ErrMissingSignature", err)
+	}
+
+	r := httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader("Body=hi"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := twilio.SignRequest([]byte("12345"), r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Header.Get(twilio.SignatureHeader) != "" || r.Header.Get(twilio.Signature256Header) == "" {
+		t.Errorf("SignRequest set %v, want only %s", r.Header, twilio.Signature256Header)
+	}
+	if err := twilio.New("12345").ValidateRequest(r); err != nil {
+		t.Errorf("SHA-256 signed request: got %v, want nil", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ComputeSignature should panic")
+		}
+	}()
+	twilio.ComputeSignature([]byte("12345"), "https://example.com/sms", nil)
+}
+
+func TestNoSHA1WithHMAC(t *testing.T) {
+	var calls int
+	v := twilio.New("12345", twilio.WithHMAC(func(key []byte) hash.Hash {
+		calls++
+		return hmac.New(sha1.New, key)
+	}, func(key []byte) hash.Hash {
+		return hmac.New(sha256.New, key)
+	}))
+	if err := v.ValidateRequest(sha1Request()); err != twilio.ErrMissingSignature {
+		t.Errorf("SHA-1 signed request: got %v, want ErrMissingSignature", err)
+	}
+	if calls != 0 {
+		t.Errorf("the SHA-1 HMAC passed to WithHMAC was used %d times", calls)
+	}
+}
@@ -0,0 +1,197 @@
+package twilio
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+// OnCloudRun configures the Validator for Google Cloud Run and Cloud
+// Functions. Google's front end terminates TLS and passes the Host header
+// through, so the signed URL is rebuilt with ForwardedProtoURL.
+//
+// Cloud Functions served from a REGION-PROJECT.cloudfunctions.net URL
+// receive requests with the function name removed from the path, so for
+// those hosts the Validator also tries the path with the name of the
+// function, read from the K_SERVICE environment variable, put back.
+//
+// Both platforms accept request bodies far larger than any Twilio webhook,
+// so the Validator keeps its own limit, DefaultMaxBodyBytes unless
+// configured otherwise.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.OnCloudRun())
+func OnCloudRun() Option {
+	service := os.Getenv("K_SERVICE")
+	return func(v *Validator) {
+		v.url = ForwardedProtoURL
+		if service != "" {
+			v.signing.variants = append(v.signing.variants, cloudFunctionVariant("/"+service))
+		}
+	}
+}
+
+// cloudFunctionVariant returns a urlVariant that adds prefix to the path of
+// URLs on cloudfunctions.net.
+func cloudFunctionVariant(prefix string) urlVariant {
+	return func(dst []string, _ *http.Request, u string) []string {
+		origin, path, rest := splitURL(u)
+		_, host, _ := strings.Cut(strings.ToLower(origin), "://")
+		if !strings.HasSuffix(stripDefaultPort(host, ""), ".cloudfunctions.net") {
+			return dst
+		}
+		if path == "/" {
+			path = ""
+		}
+		return append(dst, origin+prefix+path+rest)
+	}
+}
+
+// CloudFunction returns an HTTP function, of the kind registered with the
+// Functions Framework, that calls fn for genuine Twilio requests. It
+// validates requests with OnCloudRun followed by opts.
+//
+// Example usage:
+//
+//	func init() {
+//		functions.HTTP("Sms", twilio.CloudFunction(os.Getenv("TWILIO_AUTH_TOKEN"), sms))
+//	}
+func CloudFunction(twilioAuthToken string, fn http.HandlerFunc, opts ...Option) http.HandlerFunc {
+	return New(twilioAuthToken, append([]Option{OnCloudRun()}, opts...)...).Validate(fn)
+}
+
+// OnAppEngine configures the Validator for the App Engine standard
+// environment. App Engine's front end terminates TLS, so the scheme is
+// taken from X-Forwarded-Proto, or failing that X-AppEngine-Https, and the
+// host from r.Host. App Engine removes X-AppEngine headers sent by clients,
+// so they can be trusted.
+//
+// An app created since 2020 can be reached both at its regional hostname,
+// PROJECT.REGION_ID.r.appspot.com, and at PROJECT.appspot.com. For requests
+// to either, the Validator also tries the other, so webhooks validate
+// whichever one is configured in Twilio and whichever the front end
+// reports.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.OnAppEngine())
+func OnAppEngine() Option {
+	return func(v *Validator) {
+		v.url = appEngineURL
+		v.signing.variants = append(v.signing.variants, appspotVariant)
+	}
+}
+
+// appEngineURL is the URLFunc for App Engine.
+func appEngineURL(r *http.Request) string {
+	scheme := forwardedProto(r, 1)
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil || r.Header.Get("X-AppEngine-Https") == "on" {
+			scheme = "https"
+		}
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// appspotVariant appends u with its host switched between the regional and
+// the short appspot.com hostname of the app, as given by the
+// X-AppEngine-Default-Version-Hostname header.
+func appspotVariant(dst []string, r *http.Request, u string) []string {
+	regional := strings.ToLower(r.Header.Get("X-AppEngine-Default-Version-Hostname"))
+	project, _, ok := strings.Cut(regional, ".")
+	if !ok || !strings.HasSuffix(regional, ".r.appspot.com") {
+		return dst
+	}
+	short := project + ".appspot.com"
+	origin, path, rest := splitURL(u)
+	scheme, host, _ := strings.Cut(origin, "://")
+	switch strings.ToLower(stripDefaultPort(host, "")) {
+	case regional:
+		return append(dst, scheme+"://"+short+path+rest)
+	case short:
+		return append(dst, scheme+"://"+regional+path+rest)
+	}
+	return dst
+}
+
+// OnHeroku configures the Validator for apps behind the Heroku router, or
+// any router like it that terminates TLS, passes the Host header through,
+// and adds X-Forwarded-Proto as the only proxy in front of the app. Without
+// it, the signed URL is rebuilt with the http scheme the app sees, and
+// every webhook fails with 403 Forbidden.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.OnHeroku())
+func OnHeroku() Option {
+	return BehindProxy(1)
+}
+
+// OnFlyIO configures the Validator for apps behind the Fly.io proxy, which
+// terminates TLS, passes the Host header through, and sets
+// X-Forwarded-Proto.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.OnFlyIO())
+func OnFlyIO() Option {
+	return BehindProxy(1)
+}
+
+// OnLambda configures the Validator for AWS Lambda. Requests converted from
+// Lambda events by the twiliolambda package already carry the URL Twilio
+// signed, and are used as is. Requests arriving through an adapter that
+// serves a net/http handler on Lambda have their scheme taken from the
+// X-Forwarded-Proto header that API Gateway and load balancers add.
+//
+// Example usage:
+//
+//	v := twiliolambda.New(myAuthToken, twilio.OnLambda())
+func OnLambda() Option {
+	forwarded := proxyURL(1)
+	return WithURLFunc(func(r *http.Request) string {
+		if r.URL.IsAbs() {
+			return r.URL.String()
+		}
+		return forwarded(r)
+	})
+}
+
+// BehindProxy configures the Validator for a server behind hops proxies,
+// the first of which terminates TLS, that pass the Host header through and
+// each append the scheme they received to X-Forwarded-Proto. The scheme is
+// taken from the entry hops places from the end, since entries further
+// left may have been forged by the client. BehindProxy(0) is the same as
+// not using a proxy at all.
+//
+// Proxies that rewrite the Host header should instead be configured with
+// TrustForwardedHeaders, or with WithBaseURL if the public URL is fixed.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.BehindProxy(2)) // CDN, then load balancer
+func BehindProxy(hops int) Option {
+	if hops <= 0 {
+		return WithURLFunc(RequestURL)
+	}
+	return WithURLFunc(proxyURL(hops))
+}
+
+// proxyURL returns a URLFunc for servers behind hops proxies that each
+// append to X-Forwarded-Proto. The scheme is the entry that many places
+// from the end, since entries further left may have been forged by the
+// client. The host is r.Host.
+func proxyURL(hops int) URLFunc {
+	return func(r *http.Request) string {
+		scheme := forwardedProto(r, hops)
+		if scheme == "" {
+			scheme = "http"
+			if r.TLS != nil {
+				scheme = "https"
+			}
+		}
+		return scheme + "://" + r.Host + r.URL.RequestURI()
+	}
+}
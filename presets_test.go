@@ -0,0 +1,127 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// platformRequest returns the example request as a server behind a
+// TLS-terminating router would receive it at host and path.
+func platformRequest(host, path string) *http.Request {
+	r := exampleRequest()
+	r.Host = host
+	r.URL.Scheme, r.URL.Host, r.URL.Path = "", "", path
+	r.Header.Set("X-Forwarded-Proto", "https")
+	return r
+}
+
+func TestOnCloudRun(t *testing.T) {
+	r := platformRequest("sms-abc123-uc.a.run.app", "/sms")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://sms-abc123-uc.a.run.app/sms?foo=1&bar=2"+exampleParams))
+	if err := twilio.New("12345", twilio.OnCloudRun()).ValidateRequest(r); err != nil {
+		t.Errorf("Cloud Run: got %v, want nil", err)
+	}
+}
+
+func TestCloudFunction(t *testing.T) {
+	t.Setenv("K_SERVICE", "Sms")
+	called := false
+	fn := twilio.CloudFunction("12345", func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	// The function name is stripped from the path the function sees.
+	r := platformRequest("us-central1-myproject.cloudfunctions.net", "/")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://us-central1-myproject.cloudfunctions.net/Sms?foo=1&bar=2"+exampleParams))
+	w := httptest.NewRecorder()
+	fn(w, r)
+	if !called {
+		t.Errorf("Cloud Functions request: got status %d, want the function to be called", w.Code)
+	}
+
+	called = false
+	r = platformRequest("us-central1-myproject.cloudfunctions.net", "/")
+	r.Header.Set("X-Twilio-Signature", sign("55555", "https://us-central1-myproject.cloudfunctions.net/Sms?foo=1&bar=2"+exampleParams))
+	w = httptest.NewRecorder()
+	fn(w, r)
+	if called || w.Code != http.StatusForbidden {
+		t.Errorf("bad signature: got status %d, want 403", w.Code)
+	}
+}
+
+func TestOnAppEngine(t *testing.T) {
+	v := twilio.New("12345", twilio.OnAppEngine())
+	for _, signedHost := range []string{"myproject.uc.r.appspot.com", "myproject.appspot.com"} {
+		r := platformRequest("myproject.uc.r.appspot.com", "/sms")
+		r.Header.Del("X-Forwarded-Proto")
+		r.Header.Set("X-AppEngine-Https", "on")
+		r.Header.Set("X-AppEngine-Default-Version-Hostname", "myproject.uc.r.appspot.com")
+		r.Header.Set("X-Twilio-Signature", sign("12345", "https://"+signedHost+"/sms?foo=1&bar=2"+exampleParams))
+		if err := v.ValidateRequest(r); err != nil {
+			t.Errorf("signed for %s: got %v, want nil", signedHost, err)
+		}
+	}
+
+	// A scheme forged by the client comes before the front end's.
+	r := platformRequest("myproject.uc.r.appspot.com", "/sms")
+	r.Header.Set("X-Forwarded-Proto", "http, https")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://myproject.uc.r.appspot.com/sms?foo=1&bar=2"+exampleParams))
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("with a forged scheme: got %v, want nil", err)
+	}
+}
+
+func TestOnHeroku(t *testing.T) {
+	v := twilio.New("12345", twilio.OnHeroku())
+	r := platformRequest("myapp.herokuapp.com", "/sms")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://myapp.herokuapp.com/sms?foo=1&bar=2"+exampleParams))
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	// A scheme forged by the client comes before the router's.
+	r = platformRequest("myapp.herokuapp.com", "/sms")
+	r.Header.Set("X-Forwarded-Proto", "http, https")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "http://myapp.herokuapp.com/sms?foo=1&bar=2"+exampleParams))
+	if err := v.ValidateRequest(r); err == nil {
+		t.Error("forged scheme: got nil, want an error")
+	}
+}
+
+func TestBehindProxy(t *testing.T) {
+	target := "https://hooks.example.com/sms?foo=1&bar=2"
+	tests := []struct {
+		hops   int
+		protos string
+		valid  bool
+	}{
+		{0, "https", false},
+		{1, "https", true},
+		{1, "https, http", false},
+		{2, "https, http", true},
+		{2, "https", false}, // fewer entries than proxies
+	}
+	for _, test := range tests {
+		r := platformRequest("hooks.example.com", "/sms")
+		r.Header.Set("X-Forwarded-Proto", test.protos)
+		r.Header.Set("X-Twilio-Signature", sign("12345", target+exampleParams))
+		err := twilio.New("12345", twilio.BehindProxy(test.hops)).ValidateRequest(r)
+		if (err == nil) != test.valid {
+			t.Errorf("BehindProxy(%d) with X-Forwarded-Proto %q: got %v, want valid = %v", test.hops, test.protos, err, test.valid)
+		}
+	}
+}
+
+func TestOnLambda(t *testing.T) {
+	v := twilio.New("12345", twilio.OnLambda())
+	if err := v.ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("absolute URL: got %v, want nil", err)
+	}
+	r := platformRequest("mycompany.com", "/myapp.php")
+	if err := v.ValidateRequest(r); err != nil {
+		t.Errorf("behind API Gateway: got %v, want nil", err)
+	}
+}
@@ -0,0 +1,37 @@
+// Package redisstore provides a twilio.Store backed by Redis, so that
+// several instances of a server can share replay-protection state.
+package redisstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// A Store is a twilio.Store that records keys in Redis.
+//
+// Example usage:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	v := twilio.New(myAuthToken, twilio.WithReplayProtection(redisstore.New(rdb, "twilio:"), time.Hour))
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// New returns a Store that records keys in client, prefixed with prefix.
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+// Seen implements twilio.Store. It sets the key only if it doesn't already
+// exist, so concurrent requests on different instances agree on which one
+// saw the key first.
+func (s *Store) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	set, err := s.client.SetNX(ctx, s.prefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return !set, nil
+}
@@ -0,0 +1,31 @@
+package redisstore_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeremyschlatter/twilio-middleware/redisstore"
+)
+
+func TestSeen(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("set REDIS_ADDR to run against a Redis server")
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	prefix := "twilio-test:" + time.Now().Format(time.RFC3339Nano) + ":"
+	s := redisstore.New(rdb, prefix)
+	if seen, err := s.Seen(ctx, "a", time.Minute); err != nil || seen {
+		t.Fatalf("first Seen = %v, %v; want false, nil", seen, err)
+	}
+	if seen, err := s.Seen(ctx, "a", time.Minute); err != nil || !seen {
+		t.Fatalf("second Seen = %v, %v; want true, nil", seen, err)
+	}
+}
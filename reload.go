@@ -0,0 +1,116 @@
+package twilio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// A ReloadingToken is a TokenProvider that holds an auth token loaded from a
+// file or an environment variable, and reloads it periodically once
+// started. Rotating the token in place, for example by updating a mounted
+// Kubernetes secret, then takes effect without restarting the server.
+//
+// Example usage:
+//
+//	token := twilio.FileTokenProvider("/var/run/secrets/twilio/auth-token", time.Minute)
+//	if err := token.Start(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	v := twilio.New("", twilio.WithTokenProvider(token))
+type ReloadingToken struct {
+	load    func() ([]byte, error)
+	refresh time.Duration
+	token   atomic.Pointer[[]byte]
+
+	// OnRefreshError, if set, is called when a periodic reload fails. The
+	// previous token stays in effect.
+	OnRefreshError func(error)
+}
+
+// FileTokenProvider returns a ReloadingToken that reads the token from the
+// file at path, and rereads it every refresh once started. Leading and
+// trailing whitespace, such as a final newline, is ignored.
+func FileTokenProvider(path string, refresh time.Duration) *ReloadingToken {
+	return &ReloadingToken{refresh: refresh, load: func() ([]byte, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if b = bytes.TrimSpace(b); len(b) == 0 {
+			return nil, fmt.Errorf("twilio: auth token file %s is empty", path)
+		}
+		return b, nil
+	}}
+}
+
+// EnvTokenProvider returns a ReloadingToken that reads the token from the
+// environment variable name, and rereads it every refresh once started.
+// A process's environment only changes if the process itself changes it,
+// so to pick up secrets rotated by an orchestrator, mount them as files and
+// use FileTokenProvider.
+func EnvTokenProvider(name string, refresh time.Duration) *ReloadingToken {
+	return &ReloadingToken{refresh: refresh, load: func() ([]byte, error) {
+		v := os.Getenv(name)
+		if v == "" {
+			return nil, fmt.Errorf("twilio: environment variable %s is not set", name)
+		}
+		return []byte(v), nil
+	}}
+}
+
+// Start loads the token, then keeps reloading it in the background until
+// ctx is done. It returns an error if the initial load fails. If the
+// refresh interval is not positive, the token is loaded once and not
+// reloaded.
+func (t *ReloadingToken) Start(ctx context.Context) error {
+	if err := t.Refresh(); err != nil {
+		return err
+	}
+	if t.refresh <= 0 {
+		return nil
+	}
+	go func() {
+		tick := time.NewTicker(t.refresh)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+				if err := t.Refresh(); err != nil && t.OnRefreshError != nil {
+					t.OnRefreshError(err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh reloads the token.
+func (t *ReloadingToken) Refresh() error {
+	token, err := t.load()
+	if err != nil {
+		return err
+	}
+	t.token.Store(&token)
+	return nil
+}
+
+// errTokenNotLoaded is returned by GetToken before the token has been
+// loaded.
+var errTokenNotLoaded = errors.New("twilio: auth token not loaded; call Start or Refresh first")
+
+// GetToken returns the most recently loaded token.
+func (t *ReloadingToken) GetToken(ctx context.Context, r *http.Request) ([]byte, error) {
+	token := t.token.Load()
+	if token == nil {
+		return nil, errTokenNotLoaded
+	}
+	return *token, nil
+}
@@ -0,0 +1,87 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestFileTokenProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("55555\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	token := twilio.FileTokenProvider(path, time.Hour)
+	v := twilio.New("", twilio.WithTokenProvider(token))
+
+	var tokenErr *twilio.TokenError
+	if err := v.ValidateRequest(exampleRequest()); !errors.As(err, &tokenErr) {
+		t.Errorf("before Start: got %v, want a *TokenError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := token.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v.IsValid(exampleRequest()) {
+		t.Error("request signed with another token should not validate")
+	}
+
+	// Rotate the token.
+	if err := os.WriteFile(path, []byte("12345\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := token.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("after rotation: got %v, want nil", err)
+	}
+
+	// A failed reload keeps the previous token.
+	os.Remove(path)
+	if err := token.Refresh(); err == nil {
+		t.Error("Refresh of a missing file should fail")
+	}
+	if err := v.ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("after failed reload: got %v, want nil", err)
+	}
+}
+
+func TestEnvTokenProvider(t *testing.T) {
+	t.Setenv("TWILIO_TEST_AUTH_TOKEN", "12345")
+	token := twilio.EnvTokenProvider("TWILIO_TEST_AUTH_TOKEN", time.Hour)
+	if err := token.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if err := twilio.New("", twilio.WithTokenProvider(token)).ValidateRequest(exampleRequest()); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	if err := twilio.EnvTokenProvider("TWILIO_TEST_UNSET", time.Hour).Refresh(); err == nil {
+		t.Error("Refresh of an unset variable should fail")
+	}
+}
+
+func TestReloadingTokenNoRefresh(t *testing.T) {
+	t.Setenv("TWILIO_TEST_AUTH_TOKEN", "12345")
+	for _, refresh := range []time.Duration{0, -time.Second} {
+		token := twilio.EnvTokenProvider("TWILIO_TEST_AUTH_TOKEN", refresh)
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := token.Start(ctx); err != nil {
+			t.Fatalf("refresh %v: %v", refresh, err)
+		}
+		if err := twilio.New("", twilio.WithTokenProvider(token)).ValidateRequest(exampleRequest()); err != nil {
+			t.Errorf("refresh %v: got %v, want nil", refresh, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,181 @@
+package twilio
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// A Store remembers the requests a Validator has accepted, so that replayed
+// requests can be rejected. It must be safe for concurrent use.
+type Store interface {
+	// Seen records key and reports whether it had already been recorded
+	// within the last ttl.
+	Seen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// WithReplayProtection makes the Validator reject a request if one with the
+// same signed URL and parameters was accepted within the last window.
+// Requests that carry the I-Twilio-Idempotency-Token header, the
+// IdempotencyToken parameter of usage triggers, or CallSid and Timestamp
+// parameters are also rejected if an earlier request carried the same
+// ones, which catches Twilio's retries even if their parameters differ.
+// The header isn't covered by the signature, so it can only add to the
+// check, never replace it.
+//
+// Replayed requests fail validation with ErrReplayed. Choose window to cover
+// Twilio's retries; a request Twilio retries because your server timed out
+// is also a replay, so handlers should tolerate not seeing it a second time.
+// So is a TwiML loop, such as a <Redirect> back to the same URL, that makes
+// Twilio send an identical request within the window.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithReplayProtection(twilio.NewMemoryStore(), time.Hour))
+func WithReplayProtection(store Store, window time.Duration) Option {
+	return func(v *Validator) {
+		v.replay = store
+		v.replayWindow = window
+	}
+}
+
+// checkReplay reports whether the valid request r, whose signature covers
+// signedURL and form, and whose parameters are params, has been seen
+// before.
+func (v *Validator) checkReplay(r *http.Request, signedURL string, form, params url.Values) error {
+	for _, key := range replayKeys(r, signedURL, form, params) {
+		seen, err := v.replay.Seen(r.Context(), key, v.replayWindow)
+		if err != nil {
+			return &StoreError{err}
+		}
+		if seen {
+			return ErrReplayed
+		}
+	}
+	return nil
+}
+
+// replayKeys returns the keys that identify a request. The first is a hash
+// of what its signature covers, so it can't be changed without
+// invalidating the request. The others come from Twilio's idempotency
+// tokens, and only serve to recognize retries.
+func replayKeys(r *http.Request, signedURL string, form, params url.Values) []string {
+	h := sha256.New()
+	io.WriteString(h, signedURL)
+	io.WriteString(h, paramString(form))
+	keys := []string{"signed:" + hex.EncodeToString(h.Sum(nil))}
+	if token := r.Header.Get(IdempotencyTokenHeader); token != "" {
+		keys = append(keys, "idempotency:"+token)
+	}
+	if token := params.Get(ParamIdempotencyToken); token != "" {
+		keys = append(keys, "idempotency:"+token)
+	}
+	sid, ts := params.Get(ParamCallSid), params.Get(ParamTimestamp)
+	if sid != "" && ts != "" {
+		keys = append(keys, "call:"+sid+":"+ts)
+	}
+	return keys
+}
+
+// A MemoryStore is a Store that keeps keys in memory. It is suitable for
+// servers running as a single instance. Use NewMemoryStore to create one.
+//
+// A MemoryStore holds every key until it expires. To bound memory use, see
+// LRUStore. To share replay state between instances, see the redisstore
+// package.
+type MemoryStore struct {
+	mu        sync.Mutex
+	expires   map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{expires: make(map[string]time.Time)}
+}
+
+// Seen implements Store.
+func (s *MemoryStore) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop expired keys from time to time so the map doesn't grow forever.
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, exp := range s.expires {
+			if now.After(exp) {
+				delete(s.expires, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if exp, ok := s.expires[key]; ok && now.Before(exp) {
+		return true, nil
+	}
+	s.expires[key] = now.Add(ttl)
+	return false, nil
+}
+
+// An LRUStore is a Store that keeps at most a fixed number of keys in
+// memory, forgetting the least recently recorded keys first. Use
+// NewLRUStore to create one.
+//
+// Once full, an LRUStore may forget a key before its ttl has passed, so size
+// it to hold all the requests you expect within the replay window.
+type LRUStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of *lruEntry, most recent first
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewLRUStore returns an empty LRUStore that holds up to capacity keys. It
+// panics if capacity is less than 1.
+func NewLRUStore(capacity int) *LRUStore {
+	if capacity < 1 {
+		panic(fmt.Sprintf("twilio: NewLRUStore: capacity must be at least 1, not %d", capacity))
+	}
+	return &LRUStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Seen implements Store.
+func (s *LRUStore) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*lruEntry)
+		if now.Before(e.expires) {
+			return true, nil
+		}
+		e.expires = now.Add(ttl)
+		s.order.MoveToFront(el)
+		return false, nil
+	}
+
+	s.entries[key] = s.order.PushFront(&lruEntry{key, now.Add(ttl)})
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return false, nil
+}
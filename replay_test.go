@@ -0,0 +1,147 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestReplayProtection(t *testing.T) {
+	v := twilio.New("12345", twilio.WithReplayProtection(twilio.NewMemoryStore(), time.Hour))
+	gather := func(digits, token string) *http.Request {
+		const target = "https://mycompany.com/gather"
+		params := url.Values{"CallSid": {"CA123"}, "Digits": {digits}}
+		r, _ := http.NewRequest("POST", target, strings.NewReader(params.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Twilio-Signature", twilio.ComputeSignature([]byte("12345"), target, params))
+		if token != "" {
+			r.Header.Set("I-Twilio-Idempotency-Token", token)
+		}
+		return r
+	}
+
+	if err := v.ValidateRequest(gather("1", "abc")); err != nil {
+		t.Fatalf("first request: got %v, want nil", err)
+	}
+	if err := v.ValidateRequest(gather("1", "abc")); err != twilio.ErrReplayed {
+		t.Errorf("replayed request: got %v, want ErrReplayed", err)
+	}
+	if err := v.ValidateRequest(gather("2", "def")); err != nil {
+		t.Errorf("new request: got %v, want nil", err)
+	}
+	// A retry is recognized by its token even if its parameters differ.
+	if err := v.ValidateRequest(gather("3", "def")); err != twilio.ErrReplayed {
+		t.Errorf("retried request: got %v, want ErrReplayed", err)
+	}
+
+	// Without an idempotency token or Timestamp, the request is identified
+	// by what its signature covers.
+	if err := v.ValidateRequest(gather("4", "")); err != nil {
+		t.Errorf("request without token: got %v, want nil", err)
+	}
+	if err := v.ValidateRequest(gather("4", "")); err != twilio.ErrReplayed {
+		t.Errorf("replayed request without token: got %v, want ErrReplayed", err)
+	}
+
+	// Usage triggers carry their idempotency token as a parameter.
+	trigger := func() *http.Request {
+		const target = "https://mycompany.com/usage"
+		params := url.Values{"UsageTriggerSid": {"UT123"}, "IdempotencyToken": {"AC123-FIRES-UT123-2024-05-01"}}
+		r, _ := http.NewRequest("POST", target, strings.NewReader(params.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Twilio-Signature", twilio.ComputeSignature([]byte("12345"), target, params))
+		return r
+	}
+	if err := v.ValidateRequest(trigger()); err != nil {
+		t.Fatalf("first usage trigger: got %v, want nil", err)
+	}
+	if err := v.ValidateRequest(trigger()); err != twilio.ErrReplayed {
+		t.Errorf("replayed usage trigger: got %v, want ErrReplayed", err)
+	}
+}
+
+// The idempotency token header isn't signed, so changing or removing it
+// must not get a replayed request past the check.
+func TestReplayChangedHeader(t *testing.T) {
+	v := twilio.New("12345", twilio.WithReplayProtection(twilio.NewMemoryStore(), time.Hour))
+
+	r := exampleRequest()
+	r.Header.Set("I-Twilio-Idempotency-Token", "abc")
+	if err := v.ValidateRequest(r); err != nil {
+		t.Fatalf("first request: got %v, want nil", err)
+	}
+	for _, token := range []string{"forged", ""} {
+		r := exampleRequest()
+		r.Header.Set("I-Twilio-Idempotency-Token", token)
+		if err := v.ValidateRequest(r); err != twilio.ErrReplayed {
+			t.Errorf("replay with token %q: got %v, want ErrReplayed", token, err)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := twilio.NewMemoryStore()
+	ctx := context.Background()
+	if seen, _ := s.Seen(ctx, "a", time.Hour); seen {
+		t.Error("a should not have been seen yet")
+	}
+	if seen, _ := s.Seen(ctx, "a", time.Hour); !seen {
+		t.Error("a should have been seen")
+	}
+	if seen, _ := s.Seen(ctx, "b", -time.Second); seen {
+		t.Error("b should not have been seen yet")
+	}
+	if seen, _ := s.Seen(ctx, "b", time.Hour); seen {
+		t.Error("b should have expired")
+	}
+}
+
+func TestLRUStore(t *testing.T) {
+	s := twilio.NewLRUStore(2)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		if seen, _ := s.Seen(ctx, key, time.Hour); seen {
+			t.Errorf("%s should not have been seen yet", key)
+		}
+	}
+	if seen, _ := s.Seen(ctx, "c", time.Hour); !seen {
+		t.Error("c should have been seen")
+	}
+	// a was evicted to make room for c.
+	if seen, _ := s.Seen(ctx, "a", time.Hour); seen {
+		t.Error("a should have been evicted")
+	}
+	if seen, _ := s.Seen(ctx, "d", -time.Second); seen {
+		t.Error("d should not have been seen yet")
+	}
+	if seen, _ := s.Seen(ctx, "d", time.Hour); seen {
+		t.Error("d should have expired")
+	}
+}
+
+func TestLRUStoreCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewLRUStore(%d) should panic", capacity)
+				}
+			}()
+			twilio.NewLRUStore(capacity)
+		}()
+	}
+
+	s := twilio.NewLRUStore(1)
+	ctx := context.Background()
+	s.Seen(ctx, "a", time.Hour)
+	if seen, _ := s.Seen(ctx, "a", time.Hour); !seen {
+		t.Error("a store of capacity 1 should remember its last key")
+	}
+}
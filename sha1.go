@@ -0,0 +1,8 @@
+//go:build !twilio_nosha1
+
+package twilio
+
+import "crypto/sha1"
+
+// newSHA1 is the hash for Twilio's original signature scheme.
+var newSHA1 = sha1.New
@@ -0,0 +1,79 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// ComputeSignature returns the X-Twilio-Signature value Twilio would send
+// for a request to signedURL with POST parameters params, signed with
+// twilioAuthToken. Pass nil params for GET requests and JSON webhooks.
+//
+// It is useful for signing synthetic requests in tests and for checking
+// stored webhook logs offline. It panics if the package was built with the
+// twilio_nosha1 tag.
+func ComputeSignature(twilioAuthToken []byte, signedURL string, params url.Values) string {
+	if newSHA1 == nil {
+		panic("twilio: ComputeSignature: SHA-1 is disabled by the twilio_nosha1 build tag")
+	}
+	mac := hmac.New(newSHA1, twilioAuthToken)
+	mac.Write([]byte(signedURL + paramString(params)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ComputeSignatureSHA256 is like ComputeSignature, but returns the
+// X-Twilio-Signature-256 value of the HMAC-SHA256 scheme.
+func ComputeSignatureSHA256(twilioAuthToken []byte, signedURL string, params url.Values) string {
+	mac := hmac.New(sha256.New, twilioAuthToken)
+	mac.Write([]byte(signedURL + paramString(params)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest signs r as Twilio would, setting its X-Twilio-Signature
+// header, or X-Twilio-Signature-256 if the package was built with the
+// twilio_nosha1 tag. The signed URL is RequestURL(r), so r should have an
+// absolute URL or a Host. For a POST request with an application/json body
+// and no bodySHA256 parameter, SignRequest first adds bodySHA256 to r.URL,
+// as Twilio does for JSON webhooks.
+//
+// The body of r is left unread.
+//
+// Example usage:
+//
+//	r := httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader(form.Encode()))
+//	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+//	if err := twilio.SignRequest([]byte(testAuthToken), r); err != nil {
+//		t.Fatal(err)
+//	}
+func SignRequest(twilioAuthToken []byte, r *http.Request) error {
+	body, err := bufferBody(r, -1)
+	if err != nil {
+		return err
+	}
+	var params url.Values
+	if r.Method == "POST" {
+		ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		q := r.URL.Query()
+		if ct == "application/json" && !q.Has(ParamBodySHA256) {
+			sum := sha256.Sum256(body)
+			q.Set(ParamBodySHA256, hex.EncodeToString(sum[:]))
+			r.URL.RawQuery = q.Encode()
+		}
+		if !q.Has(ParamBodySHA256) {
+			if params, err = postForm(r, body); err != nil {
+				return err
+			}
+		}
+	}
+	if newSHA1 == nil {
+		r.Header.Set(Signature256Header, ComputeSignatureSHA256(twilioAuthToken, RequestURL(r), params))
+		return nil
+	}
+	r.Header.Set(SignatureHeader, ComputeSignature(twilioAuthToken, RequestURL(r), params))
+	return nil
+}
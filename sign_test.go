@@ -0,0 +1,63 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestComputeSignature(t *testing.T) {
+	// The example from https://www.twilio.com/docs/api/security
+	got := twilio.ComputeSignature([]byte("12345"), "https://mycompany.com/myapp.php?foo=1&bar=2", url.Values{
+		"Digits":  {"1234"},
+		"To":      {"+18005551212"},
+		"From":    {"+14158675309"},
+		"Caller":  {"+14158675309"},
+		"CallSid": {"CA1234567890ABCDE"},
+	})
+	if want := "RSOYDt4T1cUTdK1PDd93/VVr8B8="; got != want {
+		t.Errorf("ComputeSignature = %q, want %q", got, want)
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	for _, ct := range []string{"application/x-www-form-urlencoded", "application/json"} {
+		body := `Body=hello&From=%2B14158675309`
+		if ct == "application/json" {
+			body = `{"Body":"hello"}`
+		}
+		r := httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader(body))
+		r.Header.Set("Content-Type", ct)
+		if err := twilio.SignRequest([]byte("12345"), r); err != nil {
+			t.Fatal(err)
+		}
+		if err := twilio.ValidateRequest([]byte("12345"), r); err != nil {
+			t.Errorf("%s: signed request should validate, got %v", ct, err)
+		}
+	}
+
+	r := httptest.NewRequest("GET", "https://example.com/voice?CallSid=CA123", nil)
+	twilio.SignRequest([]byte("12345"), r)
+	if !twilio.IsValid([]byte("12345"), r) {
+		t.Error("signed GET request should validate")
+	}
+	if twilio.IsValid([]byte("55555"), r) {
+		t.Error("signed GET request should not validate with a different token")
+	}
+}
+
+func TestComputeSignatureSHA256(t *testing.T) {
+	r := exampleRequest()
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), r.URL.String(), url.Values{
+		"Digits": {"1234"}, "To": {"+18005551212"}, "From": {"+14158675309"},
+		"Caller": {"+14158675309"}, "CallSid": {"CA1234567890ABCDE"},
+	}))
+	if !twilio.IsValidSHA256([]byte("12345"), r) {
+		t.Error("request signed with ComputeSignatureSHA256 should validate")
+	}
+}
@@ -0,0 +1,73 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+)
+
+// A TokenProvider supplies the auth token used to validate a request. It
+// allows the token to be fetched lazily from a secrets manager, refreshed
+// while the server runs, or chosen per request.
+//
+// GetToken is called once for every request the Validator checks, so
+// implementations that do I/O should cache their result.
+type TokenProvider interface {
+	GetToken(ctx context.Context, r *http.Request) ([]byte, error)
+}
+
+// The TokenProviderFunc type is an adapter to allow the use of ordinary
+// functions as TokenProviders.
+type TokenProviderFunc func(ctx context.Context, r *http.Request) ([]byte, error)
+
+// GetToken returns f(ctx, r).
+func (f TokenProviderFunc) GetToken(ctx context.Context, r *http.Request) ([]byte, error) {
+	return f(ctx, r)
+}
+
+// WithTokenProvider makes the Validator ask p for the auth token of each
+// request instead of using the token passed to New, which may then be empty.
+// Tokens added with WithAdditionalTokens are still accepted.
+//
+// Example usage:
+//
+//	v := twilio.New("", twilio.WithTokenProvider(twilio.TokenProviderFunc(
+//		func(ctx context.Context, r *http.Request) ([]byte, error) {
+//			return secrets.Get(ctx, "twilio-auth-token")
+//		})))
+func WithTokenProvider(p TokenProvider) Option {
+	return func(v *Validator) { v.tokens = p }
+}
+
+// AccountTokens returns a TokenProvider for servers that receive webhooks
+// on behalf of many Twilio accounts or subaccounts. It reads the AccountSid
+// parameter that Twilio includes in every webhook and passes it to resolve
+// to look up that account's auth token. Requests without an AccountSid fail
+// validation with a *TokenError wrapping ErrMissingAccountSid.
+//
+// Example usage:
+//
+//	v := twilio.New("", twilio.WithTokenProvider(twilio.AccountTokens(
+//		func(ctx context.Context, accountSid string) ([]byte, error) {
+//			return db.AuthTokenForAccount(ctx, accountSid)
+//		})))
+func AccountTokens(resolve func(ctx context.Context, accountSid string) ([]byte, error)) TokenProvider {
+	return TokenProviderFunc(func(ctx context.Context, r *http.Request) ([]byte, error) {
+		sid := r.FormValue(ParamAccountSid)
+		if sid == "" {
+			return nil, ErrMissingAccountSid
+		}
+		return resolve(ctx, sid)
+	})
+}
+
+// keysFor returns the keys that r may have been signed with.
+func (v *Validator) keysFor(r *http.Request) ([][]byte, error) {
+	if v.tokens == nil {
+		return v.keys, nil
+	}
+	key, err := v.tokens.GetToken(r.Context(), r)
+	if err != nil {
+		return nil, &TokenError{err}
+	}
+	return append([][]byte{key}, v.keys[1:]...), nil
+}
@@ -0,0 +1,62 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func TestWithTokenProvider(t *testing.T) {
+	token := []byte("12345")
+	v := twilio.New("", twilio.WithTokenProvider(twilio.TokenProviderFunc(
+		func(ctx context.Context, r *http.Request) ([]byte, error) {
+			return token, nil
+		})))
+	if !v.IsValid(exampleRequest()) {
+		t.Error("request should validate with the provided token")
+	}
+
+	token = []byte("55555")
+	if v.IsValid(exampleRequest()) {
+		t.Error("request should not validate once the provided token changes")
+	}
+
+	errUnavailable := errors.New("secret store unavailable")
+	v = twilio.New("12345", twilio.WithTokenProvider(twilio.TokenProviderFunc(
+		func(ctx context.Context, r *http.Request) ([]byte, error) {
+			return nil, errUnavailable
+		})))
+	err := v.ValidateRequest(exampleRequest())
+	var tokenErr *twilio.TokenError
+	if !errors.As(err, &tokenErr) || !errors.Is(err, errUnavailable) {
+		t.Errorf("got %v, want a *TokenError wrapping the provider's error", err)
+	}
+}
+
+func TestAccountTokens(t *testing.T) {
+	tokens := map[string][]byte{"AC123": []byte("12345")}
+	v := twilio.New("", twilio.WithTokenProvider(twilio.AccountTokens(
+		func(ctx context.Context, accountSid string) ([]byte, error) {
+			if token, ok := tokens[accountSid]; ok {
+				return token, nil
+			}
+			return nil, errors.New("unknown account")
+		})))
+
+	// The example request has no AccountSid.
+	if err := v.ValidateRequest(exampleRequest()); !errors.Is(err, twilio.ErrMissingAccountSid) {
+		t.Errorf("got %v, want ErrMissingAccountSid", err)
+	}
+
+	r := exampleRequest()
+	r.URL.RawQuery += "&AccountSid=AC123"
+	r.Header.Set("X-Twilio-Signature", sign("12345", r.URL.String()+exampleParams))
+	if !v.IsValid(r) {
+		t.Error("request should validate with the account's token")
+	}
+}
@@ -0,0 +1,181 @@
+// Package twilioazure validates Twilio webhooks delivered to Azure
+// Functions custom handlers through an HTTP trigger.
+//
+// The Functions host wraps each request in a JSON invocation envelope. The
+// package rebuilds the request Twilio made from the envelope and validates
+// it with a twilio.Validator, so all of that package's options apply, and
+// wraps the response in the envelope the host expects in return.
+package twilioazure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/internal/adapt"
+)
+
+// An InvokeRequest is the envelope in which the Functions host passes an
+// invocation to a custom handler. Data holds the trigger and input
+// bindings by name.
+type InvokeRequest struct {
+	Data     map[string]json.RawMessage
+	Metadata map[string]json.RawMessage
+}
+
+// An HTTPRequest is the payload of an HTTP trigger binding.
+type HTTPRequest struct {
+	// URL is the URL of the request as the Functions host received it,
+	// including the query string.
+	URL     string `json:"Url"`
+	Method  string
+	Query   map[string]string
+	Headers map[string][]string
+	Params  map[string]string
+
+	// Body is the request body: a JSON string for text bodies such as
+	// Twilio's form-encoded webhooks, or the parsed value for JSON bodies.
+	Body json.RawMessage
+}
+
+// An InvokeResponse is the envelope in which a custom handler returns the
+// results of an invocation. Outputs holds the output bindings by name.
+type InvokeResponse struct {
+	Outputs     map[string]any
+	Logs        []string
+	ReturnValue any
+}
+
+// An HTTPResponse is the payload of an HTTP output binding.
+type HTTPResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Body       string            `json:"body"`
+	Headers    map[string]string `json:"headers,omitempty"`
+}
+
+// A Validator validates requests from an HTTP trigger.
+//
+// Example usage:
+//
+//	v := twilioazure.New(myAuthToken)
+//	http.Handle("/sms", v.Handler("req", "res", smsHandler))
+//	log.Fatal(http.ListenAndServe(":"+os.Getenv("FUNCTIONS_CUSTOMHANDLER_PORT"), nil))
+type Validator struct {
+	v *twilio.Validator
+}
+
+// New returns a Validator that checks requests with a twilio.Validator
+// configured with opts.
+func New(twilioAuthToken string, opts ...twilio.Option) *Validator {
+	return FromValidator(twilio.New(twilioAuthToken, opts...))
+}
+
+// FromValidator returns a Validator that checks requests with v.
+func FromValidator(v *twilio.Validator) *Validator {
+	return &Validator{v: v}
+}
+
+// Handler returns the custom handler for a function with an HTTP trigger
+// binding named trigger and an HTTP output binding named output, usually
+// "req" and "res". It calls next with the request Twilio made, rebuilt from
+// the trigger binding, if it is genuine, and the twilio.Validator's failure
+// handler otherwise, and returns whatever they write as the output binding.
+//
+// If the invocation envelope can't be decoded, Handler responds to the
+// Functions host itself with 400 Bad Request.
+func (av *Validator) Handler(trigger, output string, next http.Handler) http.Handler {
+	h := av.v.Middleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeTrigger(r, trigger)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var res *HTTPResponse
+		if hr, err := Request(r.Context(), req); err != nil {
+			res = &HTTPResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+		} else {
+			rec := adapt.NewRecorder()
+			h.ServeHTTP(rec, hr)
+			res = response(rec)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(InvokeResponse{Outputs: map[string]any{output: res}})
+	})
+}
+
+// HTTPTrigger validates the payload of an HTTP trigger binding, for custom
+// handlers that build their own InvokeResponse. If it is a genuine Twilio
+// request, HTTPTrigger returns the request with its twilio.Result in its
+// context. Otherwise it returns the response to send instead: the
+// twilio.Validator's failure response, or 400 Bad Request if the payload
+// can't be converted.
+func (av *Validator) HTTPTrigger(ctx context.Context, req HTTPRequest) (*http.Request, *HTTPResponse) {
+	r, err := Request(ctx, req)
+	if err != nil {
+		return nil, &HTTPResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+	}
+	r, w := adapt.Check(av.v, r)
+	if w != nil {
+		return nil, response(w)
+	}
+	return r, nil
+}
+
+// decodeTrigger reads the HTTP trigger binding named trigger from the
+// invocation envelope in the body of r.
+func decodeTrigger(r *http.Request, trigger string) (HTTPRequest, error) {
+	var inv InvokeRequest
+	var req HTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&inv); err != nil {
+		return req, fmt.Errorf("twilioazure: decoding invocation: %w", err)
+	}
+	data, ok := inv.Data[trigger]
+	if !ok {
+		return req, fmt.Errorf("twilioazure: invocation has no %q binding", trigger)
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return req, fmt.Errorf("twilioazure: decoding %q binding: %w", trigger, err)
+	}
+	return req, nil
+}
+
+// Request converts the payload of an HTTP trigger binding into an
+// *http.Request for the URL the Functions host received, with the scheme
+// taken from X-Forwarded-Proto if the front end set it. If the function
+// app sits behind a proxy or custom domain, configure the public URL with
+// twilio.WithBaseURL.
+//
+// JSON bodies reach the custom handler parsed and re-encoded, so JSON
+// webhooks, whose signatures cover a hash of the exact body, don't
+// validate. Twilio's form-encoded webhooks are passed through unchanged.
+func Request(ctx context.Context, req HTTPRequest) (*http.Request, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("twilioazure: %w", err)
+	}
+	header := make(http.Header, len(req.Headers))
+	for k, vs := range req.Headers {
+		for _, v := range vs {
+			header.Add(k, v)
+		}
+	}
+	u.Scheme = adapt.Scheme(header, u.Scheme)
+	body := []byte(req.Body)
+	if len(body) > 0 && body[0] == '"' {
+		var s string
+		if err := json.Unmarshal(body, &s); err != nil {
+			return nil, fmt.Errorf("twilioazure: decoding body: %w", err)
+		}
+		body = []byte(s)
+	}
+	return adapt.NewRequest(ctx, req.Method, u.String(), header, body)
+}
+
+// response returns what was written to w as an HTTP output binding.
+func response(w *adapt.Recorder) *HTTPResponse {
+	return &HTTPResponse{StatusCode: w.StatusCode(), Body: w.Body.String(), Headers: w.SingleHeaders()}
+}
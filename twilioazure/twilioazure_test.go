@@ -0,0 +1,82 @@
+package twilioazure_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twilioazure"
+)
+
+var form = url.Values{"From": {"+14158675309"}, "Body": {"hi"}}
+
+const signed = "https://myapp.azurewebsites.net/api/sms?a=1"
+
+func trigger(signature string) twilioazure.HTTPRequest {
+	body, _ := json.Marshal(form.Encode())
+	return twilioazure.HTTPRequest{
+		URL:    "http://myapp.azurewebsites.net/api/sms?a=1",
+		Method: "POST",
+		Headers: map[string][]string{
+			"Content-Type":           {"application/x-www-form-urlencoded"},
+			"X-Forwarded-Proto":      {"https"},
+			"X-Twilio-Signature-256": {signature},
+		},
+		Body: body,
+	}
+}
+
+func invoke(t *testing.T, h http.Handler, req twilioazure.HTTPRequest) *twilioazure.HTTPResponse {
+	t.Helper()
+	data, _ := json.Marshal(req)
+	inv, _ := json.Marshal(twilioazure.InvokeRequest{Data: map[string]json.RawMessage{"req": data}})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/sms", strings.NewReader(string(inv))))
+	var out struct {
+		Outputs map[string]*twilioazure.HTTPResponse
+	}
+	if err := json.NewDecoder(w.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding invocation response: %v", err)
+	}
+	return out.Outputs["res"]
+}
+
+func TestHandler(t *testing.T) {
+	h := twilioazure.New("12345").Handler("req", "res", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := twilio.FromContext(r.Context())
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte("<Response>" + res.Params.Get("Body") + "</Response>"))
+	}))
+
+	res := invoke(t, h, trigger(twilio.ComputeSignatureSHA256([]byte("12345"), signed, form)))
+	if res.StatusCode != http.StatusOK || res.Body != "<Response>hi</Response>" || res.Headers["Content-Type"] != "text/xml" {
+		t.Errorf("valid request: got %+v", res)
+	}
+
+	res = invoke(t, h, trigger(twilio.ComputeSignatureSHA256([]byte("55555"), signed, form)))
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("bad signature: got status %d, want 403", res.StatusCode)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/sms", strings.NewReader(`{"Data":{}}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing binding: got status %d, want 400", w.Code)
+	}
+}
+
+func TestHTTPTrigger(t *testing.T) {
+	v := twilioazure.New("12345")
+	r, failed := v.HTTPTrigger(context.Background(), trigger(twilio.ComputeSignatureSHA256([]byte("12345"), signed, form)))
+	if failed != nil {
+		t.Fatalf("got failure response %d: %s", failed.StatusCode, failed.Body)
+	}
+	if res, _ := twilio.FromContext(r.Context()); res.Params.Get("From") != "+14158675309" {
+		t.Errorf("From = %q, want +14158675309", res.Params.Get("From"))
+	}
+}
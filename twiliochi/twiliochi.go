@@ -0,0 +1,68 @@
+// Package twiliochi adds chi-specific helpers to the twilio package. The
+// twilio.Middleware function already has the signature chi expects; this
+// package adds URL reconstruction and token selection based on chi's
+// routing information.
+package twiliochi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/internal/route"
+)
+
+// Middleware is twilio.Middleware, for symmetry with the other adapters.
+func Middleware(twilioAuthToken string, opts ...twilio.Option) func(http.Handler) http.Handler {
+	return twilio.Middleware(twilioAuthToken, opts...)
+}
+
+// URLTemplate returns a twilio.URLFunc for webhooks whose URL in Twilio's
+// configuration differs from the route chi serves them on. Placeholders
+// such as {callSid} in template are replaced with the chi URL parameter of
+// the same name, and the query string of the request is appended.
+//
+// The URL parameters are only known once chi has matched the route, so use
+// the Validator as inline middleware on the route itself, with With.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithURLFunc(
+//		twiliochi.URLTemplate("https://hooks.example.com/calls/{callSid}/status")))
+//	r.With(v.Middleware).Post("/internal/calls/{callSid}/status", statusHandler)
+func URLTemplate(template string) twilio.URLFunc {
+	return func(r *http.Request) string {
+		return route.Expand(template, r, func(name string) string {
+			return chi.URLParam(r, name)
+		})
+	}
+}
+
+// RouteTokens returns a twilio.TokenProvider that chooses the auth token
+// for a request by the chi route pattern it matched, such as
+// "/support/voice". Requests on routes with no token fail validation.
+//
+// As with URLTemplate, the route pattern is only complete once chi has
+// matched the route, so use the Validator as inline middleware with With.
+//
+// Example usage:
+//
+//	v := twilio.New("", twilio.WithTokenProvider(twiliochi.RouteTokens(map[string]string{
+//		"/support/voice": supportAuthToken,
+//		"/sales/voice":   salesAuthToken,
+//	})))
+//	r.With(v.Middleware).Post("/support/voice", supportHandler)
+//	r.With(v.Middleware).Post("/sales/voice", salesHandler)
+func RouteTokens(tokens map[string]string) twilio.TokenProvider {
+	return twilio.TokenProviderFunc(func(ctx context.Context, r *http.Request) ([]byte, error) {
+		pattern := chi.RouteContext(r.Context()).RoutePattern()
+		token, ok := tokens[pattern]
+		if !ok {
+			return nil, fmt.Errorf("twiliochi: no auth token for route %q", pattern)
+		}
+		return []byte(token), nil
+	})
+}
@@ -0,0 +1,62 @@
+package twiliochi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliochi"
+)
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+func serve(h http.Handler, method, target, signed, token string) int {
+	r := httptest.NewRequest(method, target, nil)
+	r.Header.Set(twilio.Signature256Header, twilio.ComputeSignatureSHA256([]byte(token), signed, nil))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestURLTemplate(t *testing.T) {
+	v := twilio.New("12345", twilio.WithURLFunc(
+		twiliochi.URLTemplate("https://hooks.example.com/calls/{callSid}/status")))
+	r := chi.NewRouter()
+	r.With(v.Middleware).Get("/internal/calls/{callSid:CA[0-9a-f]+}/status", ok)
+
+	if code := serve(r, "GET", "/internal/calls/CA123/status?x=1", "https://hooks.example.com/calls/CA123/status?x=1", "12345"); code != http.StatusOK {
+		t.Errorf("got %d, want 200", code)
+	}
+	if code := serve(r, "GET", "/internal/calls/CA123/status", "https://hooks.example.com/calls/CA456/status", "12345"); code != http.StatusForbidden {
+		t.Errorf("signature for another call: got %d, want 403", code)
+	}
+}
+
+func TestRouteTokens(t *testing.T) {
+	v := twilio.New("", twilio.WithTokenProvider(twiliochi.RouteTokens(map[string]string{
+		"/a/{id}": "aaaaa",
+		"/b/{id}": "bbbbb",
+	})))
+	r := chi.NewRouter()
+	r.With(v.Middleware).Get("/a/{id}", ok)
+	r.With(v.Middleware).Get("/b/{id}", ok)
+	r.With(v.Middleware).Get("/c/{id}", ok)
+
+	tests := []struct {
+		path, token string
+		code        int
+	}{
+		{"/a/1", "aaaaa", http.StatusOK},
+		{"/b/1", "bbbbb", http.StatusOK},
+		{"/a/1", "bbbbb", http.StatusForbidden},
+		{"/c/1", "aaaaa", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		if code := serve(r, "GET", test.path, "http://example.com"+test.path, test.token); code != test.code {
+			t.Errorf("%s signed with %s: got %d, want %d", test.path, test.token, code, test.code)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package twiliofiber adapts twilio.Validator to the Fiber web framework,
+// which is built on fasthttp rather than net/http.
+//
+// A twilio.Validator's options, such as its URL function, token provider
+// and SkipWhen predicate, all take an *http.Request, so the request still
+// has to be presented as one. Validating from fasthttp directly would need
+// a second set of those hooks. Instead of a full conversion, Request builds
+// a shallow *http.Request that shares the body with fasthttp and has only
+// the fields validation reads.
+package twiliofiber
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+// ResultKey is the key under which Middleware stores the *twilio.Result of
+// validating a request in the Fiber context's locals.
+const ResultKey = "twilio.result"
+
+// requestKey is the key under which Middleware stores the validated
+// *http.Request in the Fiber context's locals, for Decode.
+type requestKey struct{}
+
+// Middleware returns a Fiber handler that validates requests with a
+// twilio.Validator configured with opts, and passes valid ones on to the
+// next handler. For valid requests, the result of validation is stored in
+// the context's locals; see Params and Decode.
+//
+// Failure handlers set with twilio.WithFailureHandler don't apply. Instead,
+// a request that fails validation makes the handler return a *fiber.Error
+// whose code is twilio.StatusCode of the failure, 403 Forbidden by default,
+// for the app's error handler to respond to.
+//
+// Example usage:
+//
+//	app := fiber.New()
+//	app.Post("/sms", twiliofiber.Middleware(myAuthToken), func(c *fiber.Ctx) error {
+//		from := twiliofiber.Params(c).Get("From")
+//		...
+//	})
+func Middleware(twilioAuthToken string, opts ...twilio.Option) fiber.Handler {
+	return FromValidator(twilio.New(twilioAuthToken, opts...))
+}
+
+// FromValidator is like Middleware, but uses an existing Validator.
+func FromValidator(v *twilio.Validator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		r, err := Request(c.Context())
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		res := v.Check(r)
+		if !res.Valid && !res.Skipped {
+			code := twilio.StatusCode(res.Err)
+			return fiber.NewError(code, http.StatusText(code))
+		}
+		c.Locals(ResultKey, res)
+		c.Locals(requestKey{}, r.WithContext(twilio.NewContext(r.Context(), res)))
+		return c.Next()
+	}
+}
+
+// Request returns an *http.Request describing ctx, with just the fields a
+// twilio.Validator uses. Its body shares memory with ctx rather than being
+// copied, and it is not a full conversion: it is only meant for validation
+// and for the decoders in the webhook package.
+func Request(ctx *fasthttp.RequestCtx) (*http.Request, error) {
+	requestURI := string(ctx.RequestURI())
+	u, err := url.ParseRequestURI(requestURI)
+	if err != nil {
+		return nil, err
+	}
+	body := ctx.PostBody()
+	r := &http.Request{
+		Method:        string(ctx.Method()),
+		URL:           u,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Host:          string(ctx.Host()),
+		RemoteAddr:    ctx.RemoteAddr().String(),
+		RequestURI:    requestURI,
+		TLS:           ctx.TLSConnectionState(),
+	}
+	for k, v := range ctx.Request.Header.All() {
+		r.Header.Add(string(k), string(v))
+	}
+	return r.WithContext(ctx), nil
+}
+
+// Result returns the result of validating the request in c, or nil if it
+// didn't pass through Middleware.
+func Result(c *fiber.Ctx) *twilio.Result {
+	res, _ := c.Locals(ResultKey).(*twilio.Result)
+	return res
+}
+
+// Params returns the webhook parameters of the request in c, or nil if it
+// didn't pass through Middleware.
+func Params(c *fiber.Ctx) url.Values {
+	if res := Result(c); res != nil {
+		return res.Params
+	}
+	return nil
+}
+
+// Decode decodes the parameters of the request in c, which must have passed
+// through Middleware, with decode, which is one of the decoders in the
+// webhook package. If the request didn't pass through Middleware, Decode
+// returns webhook.ErrNotValidated.
+//
+// Example usage:
+//
+//	app.Post("/sms", twiliofiber.Middleware(myAuthToken), func(c *fiber.Ctx) error {
+//		msg, err := twiliofiber.Decode(c, webhook.DecodeMessage)
+//		if err != nil {
+//			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+//		}
+//		...
+//	})
+func Decode[T any](c *fiber.Ctx, decode func(*http.Request) (T, error)) (T, error) {
+	r, ok := c.Locals(requestKey{}).(*http.Request)
+	if !ok {
+		var zero T
+		return zero, webhook.ErrNotValidated
+	}
+	return decode(r)
+}
@@ -0,0 +1,102 @@
+package twiliofiber_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliofiber"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestMiddleware(t *testing.T) {
+	app := fiber.New()
+	var from string
+	app.Post("/sms", twiliofiber.Middleware("12345"), func(c *fiber.Ctx) error {
+		from = twiliofiber.Params(c).Get("From")
+		return c.SendString("ok")
+	})
+
+	form := url.Values{"From": {"+14158675309"}, "Body": {"hi"}}
+	newRequest := func() *http.Request {
+		r, _ := http.NewRequest("POST", "http://example.com/sms?x=1", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	r := newRequest()
+	if err := twilio.SignRequest([]byte("12345"), r); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := app.Test(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("valid request: got %d, want 200", resp.StatusCode)
+	}
+	if from != "+14158675309" {
+		t.Errorf("From = %q", from)
+	}
+
+	r = newRequest()
+	if err := twilio.SignRequest([]byte("55555"), r); err != nil {
+		t.Fatal(err)
+	}
+	from = ""
+	resp, err = app.Test(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("invalid request: got %d, want 403", resp.StatusCode)
+	}
+	if from != "" {
+		t.Error("handler should not run for invalid requests")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	app := fiber.New()
+	var body string
+	var decodeErr error
+	app.Post("/sms", twiliofiber.Middleware("12345"), func(c *fiber.Ctx) error {
+		msg, err := twiliofiber.Decode(c, webhook.DecodeMessage)
+		if err == nil {
+			body = msg.Body
+		}
+		return err
+	})
+	app.Post("/unprotected", func(c *fiber.Ctx) error {
+		_, decodeErr = twiliofiber.Decode(c, webhook.DecodeMessage)
+		return nil
+	})
+
+	form := url.Values{"MessageSid": {"SM123"}, "Body": {"hi"}}
+	r, _ := http.NewRequest("POST", "http://example.com/sms", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := twilio.SignRequest([]byte("12345"), r); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := app.Test(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || body != "hi" {
+		t.Errorf("got %d and Body %q, want 200 and \"hi\"", resp.StatusCode, body)
+	}
+
+	r, _ = http.NewRequest("POST", "http://example.com/unprotected", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := app.Test(r); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(decodeErr, webhook.ErrNotValidated) {
+		t.Errorf("without Middleware: got %v, want ErrNotValidated", decodeErr)
+	}
+}
@@ -0,0 +1,68 @@
+// Package twiliogin adapts twilio.Validator to the gin web framework.
+package twiliogin
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// ResultKey is the gin context key under which Middleware stores the
+// *twilio.Result of validating a request.
+const ResultKey = "twilio.result"
+
+// Middleware returns gin middleware that validates requests with a
+// twilio.Validator configured with opts. Requests that fail validation get
+// the Validator's failure response, 403 Forbidden by default, and the
+// handler chain is aborted. For valid requests, the result of validation is
+// stored in the gin context; see Params.
+//
+// Example usage:
+//
+//	r := gin.Default()
+//	hooks := r.Group("/twilio", twiliogin.Middleware(myAuthToken))
+//	hooks.POST("/sms", func(c *gin.Context) {
+//		from := twiliogin.Params(c).Get("From")
+//		...
+//	})
+func Middleware(twilioAuthToken string, opts ...twilio.Option) gin.HandlerFunc {
+	return FromValidator(twilio.New(twilioAuthToken, opts...))
+}
+
+// FromValidator is like Middleware, but uses an existing Validator.
+func FromValidator(v *twilio.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		passed := false
+		v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			passed = true
+			c.Request = r
+		})).ServeHTTP(c.Writer, c.Request)
+		if !passed {
+			c.Abort()
+			return
+		}
+		res, _ := twilio.FromContext(c.Request.Context())
+		c.Set(ResultKey, res)
+		c.Next()
+	}
+}
+
+// Result returns the result of validating the request in c, or nil if it
+// didn't pass through Middleware.
+func Result(c *gin.Context) *twilio.Result {
+	res, _ := c.Get(ResultKey)
+	r, _ := res.(*twilio.Result)
+	return r
+}
+
+// Params returns the webhook parameters of the request in c, or nil if it
+// didn't pass through Middleware.
+func Params(c *gin.Context) url.Values {
+	if res := Result(c); res != nil {
+		return res.Params
+	}
+	return nil
+}
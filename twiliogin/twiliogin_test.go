@@ -0,0 +1,52 @@
+package twiliogin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliogin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var from string
+	router.POST("/sms", twiliogin.Middleware("12345"), func(c *gin.Context) {
+		from = twiliogin.Params(c).Get("From")
+		c.String(http.StatusOK, "ok")
+	})
+
+	form := url.Values{"From": {"+14158675309"}, "Body": {"hi"}}
+	r := httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := twilio.SignRequest([]byte("12345"), r); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("valid request: got %d, want 200", w.Code)
+	}
+	if from != "+14158675309" {
+		t.Errorf("From = %q", from)
+	}
+
+	r = httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature", "bm90IHJpZ2h0")
+	from = ""
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("invalid request: got %d, want 403", w.Code)
+	}
+	if from != "" {
+		t.Error("handler should not run for invalid requests")
+	}
+}
@@ -0,0 +1,70 @@
+// Package twiliogorilla adapts twilio.Validator to gorilla/mux, with URL
+// reconstruction based on the route a request matched.
+package twiliogorilla
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/internal/route"
+)
+
+// Middleware returns a mux.MiddlewareFunc that validates requests with a
+// twilio.Validator configured with opts. gorilla/mux runs middleware after
+// matching a route, so URL functions such as URLTemplate and RouteURLs can
+// use the route.
+//
+// Example usage:
+//
+//	r := mux.NewRouter()
+//	r.Use(twiliogorilla.Middleware(myAuthToken, twilio.WithURLFunc(
+//		twiliogorilla.URLTemplate("https://hooks.example.com/calls/{callSid}/status"))))
+//	r.HandleFunc("/internal/calls/{callSid}/status", statusHandler).Methods("POST")
+func Middleware(twilioAuthToken string, opts ...twilio.Option) mux.MiddlewareFunc {
+	return twilio.New(twilioAuthToken, opts...).Middleware
+}
+
+// URLTemplate returns a twilio.URLFunc for webhooks whose URL in Twilio's
+// configuration differs from the route that serves them. Variables such as
+// {callSid} in template are replaced with the route variable of the same
+// name, and the query string of the request is appended.
+func URLTemplate(template string) twilio.URLFunc {
+	return func(r *http.Request) string {
+		return expand(template, r)
+	}
+}
+
+// RouteURLs returns a twilio.URLFunc that picks a URL template by the name
+// of the route the request matched, as set with Route.Name, and expands it
+// as URLTemplate does. Requests on routes without a template use fallback,
+// or twilio.RequestURL if fallback is nil.
+//
+// Example usage:
+//
+//	urls := twiliogorilla.RouteURLs(map[string]string{
+//		"call-status": "https://hooks.example.com/calls/{callSid}/status",
+//	}, nil)
+//	r.Use(twiliogorilla.Middleware(myAuthToken, twilio.WithURLFunc(urls)))
+//	r.HandleFunc("/internal/calls/{callSid}/status", statusHandler).Name("call-status")
+func RouteURLs(templates map[string]string, fallback twilio.URLFunc) twilio.URLFunc {
+	if fallback == nil {
+		fallback = twilio.RequestURL
+	}
+	return func(r *http.Request) string {
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, ok := templates[route.GetName()]; ok {
+				return expand(template, r)
+			}
+		}
+		return fallback(r)
+	}
+}
+
+// expand fills in the variables of template from r's route variables and
+// appends r's query string.
+func expand(template string, r *http.Request) string {
+	vars := mux.Vars(r)
+	return route.Expand(template, r, func(name string) string { return vars[name] })
+}
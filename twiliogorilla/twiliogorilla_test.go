@@ -0,0 +1,53 @@
+package twiliogorilla_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliogorilla"
+)
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+func serve(h http.Handler, target, signed string) int {
+	r := httptest.NewRequest("GET", target, nil)
+	r.Header.Set(twilio.Signature256Header, twilio.ComputeSignatureSHA256([]byte("12345"), signed, nil))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestURLTemplate(t *testing.T) {
+	r := mux.NewRouter()
+	r.Use(twiliogorilla.Middleware("12345", twilio.WithURLFunc(
+		twiliogorilla.URLTemplate("https://hooks.example.com/calls/{callSid}/status"))))
+	r.HandleFunc("/internal/calls/{callSid:CA[0-9a-f]+}/status", ok)
+
+	if code := serve(r, "/internal/calls/CA123/status?x=1", "https://hooks.example.com/calls/CA123/status?x=1"); code != http.StatusOK {
+		t.Errorf("got %d, want 200", code)
+	}
+	if code := serve(r, "/internal/calls/CA123/status", "https://hooks.example.com/calls/CA456/status"); code != http.StatusForbidden {
+		t.Errorf("signature for another call: got %d, want 403", code)
+	}
+}
+
+func TestRouteURLs(t *testing.T) {
+	urls := twiliogorilla.RouteURLs(map[string]string{
+		"status": "https://hooks.example.com/calls/{callSid}/status",
+	}, nil)
+	r := mux.NewRouter()
+	r.Use(twiliogorilla.Middleware("12345", twilio.WithURLFunc(urls)))
+	r.HandleFunc("/internal/{callSid}/status", ok).Name("status")
+	r.HandleFunc("/sms", ok)
+
+	if code := serve(r, "/internal/CA123/status", "https://hooks.example.com/calls/CA123/status"); code != http.StatusOK {
+		t.Errorf("named route: got %d, want 200", code)
+	}
+	if code := serve(r, "/sms", "http://example.com/sms"); code != http.StatusOK {
+		t.Errorf("fallback: got %d, want 200", code)
+	}
+}
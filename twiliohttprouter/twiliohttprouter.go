@@ -0,0 +1,34 @@
+// Package twiliohttprouter adapts twilio.Validator to httprouter's handle
+// signature.
+package twiliohttprouter
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// Validate returns an httprouter.Handle that calls protected, with the
+// route's Params, for requests that pass validation by a twilio.Validator
+// configured with opts. Other requests get the Validator's failure
+// response, 403 Forbidden by default.
+//
+// Example usage:
+//
+//	router := httprouter.New()
+//	router.POST("/calls/:sid/status", twiliohttprouter.Validate(myAuthToken, statusHandler))
+func Validate(twilioAuthToken string, protected httprouter.Handle, opts ...twilio.Option) httprouter.Handle {
+	return Protect(twilio.New(twilioAuthToken, opts...), protected)
+}
+
+// Protect is like Validate, but uses an existing Validator, so that several
+// routes can share one.
+func Protect(v *twilio.Validator, protected httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			protected(w, r, ps)
+		})).ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,45 @@
+package twiliohttprouter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliohttprouter"
+)
+
+func TestValidate(t *testing.T) {
+	var sid string
+	var res *twilio.Result
+	router := httprouter.New()
+	router.GET("/calls/:sid/status", twiliohttprouter.Validate("12345", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		sid = ps.ByName("sid")
+		res, _ = twilio.FromContext(r.Context())
+	}))
+
+	for _, test := range []struct {
+		key  string
+		code int
+	}{
+		{"12345", http.StatusOK},
+		{"55555", http.StatusForbidden},
+	} {
+		sid, res = "", nil
+		r := httptest.NewRequest("GET", "/calls/CA123/status", nil)
+		r.Header.Set(twilio.Signature256Header, twilio.ComputeSignatureSHA256([]byte(test.key), "http://example.com/calls/CA123/status", nil))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("signed with %s: got %d, want %d", test.key, w.Code, test.code)
+		}
+		if test.code == http.StatusOK && (sid != "CA123" || res == nil || !res.Valid) {
+			t.Errorf("signed with %s: handler saw sid %q, result %v", test.key, sid, res)
+		}
+		if test.code != http.StatusOK && sid != "" {
+			t.Errorf("signed with %s: handler should not run", test.key)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package twiliolambda validates Twilio webhooks delivered to AWS Lambda
+// functions by API Gateway, Lambda function URLs, or Application Load
+// Balancers.
+//
+// The events are converted into *http.Request values and validated by a
+// twilio.Validator, so all of the package's options apply. A valid request
+// carries its twilio.Result in its context, as it would behind
+// twilio.Middleware, so the decoders in the webhook package turn it into
+// the typed webhook structs.
+package twiliolambda
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/internal/adapt"
+)
+
+// A Validator validates Lambda events.
+//
+// Example usage:
+//
+//	v := twiliolambda.New(myAuthToken)
+//
+//	func handle(ctx context.Context, e events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+//		r, failed := v.APIGateway(ctx, e)
+//		if failed != nil {
+//			return *failed, nil
+//		}
+//		msg, err := webhook.DecodeMessage(r)
+//		if err != nil {
+//			return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, nil
+//		}
+//		...
+//	}
+type Validator struct {
+	v *twilio.Validator
+}
+
+// New returns a Validator that checks events with a twilio.Validator
+// configured with opts.
+func New(twilioAuthToken string, opts ...twilio.Option) *Validator {
+	return FromValidator(twilio.New(twilioAuthToken, opts...))
+}
+
+// FromValidator returns a Validator that checks events with v.
+func FromValidator(v *twilio.Validator) *Validator {
+	return &Validator{v: v}
+}
+
+// APIGateway validates an event from an API Gateway REST API (payload
+// format 1.0). If the event is a genuine Twilio request, it returns the
+// request with its twilio.Result in its context. Otherwise it returns the
+// response to send instead: the twilio.Validator's failure response, or
+// 400 Bad Request if the event can't be converted.
+func (lv *Validator) APIGateway(ctx context.Context, e events.APIGatewayProxyRequest) (*http.Request, *events.APIGatewayProxyResponse) {
+	r, err := APIGatewayRequest(ctx, e)
+	if err != nil {
+		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+	}
+	r, w := adapt.Check(lv.v, r)
+	if w != nil {
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode:        w.StatusCode(),
+			MultiValueHeaders: w.HeaderMap,
+			Body:              w.Body.String(),
+		}
+	}
+	return r, nil
+}
+
+// APIGatewayV2 is like APIGateway, but for events from an API Gateway HTTP
+// API (payload format 2.0).
+func (lv *Validator) APIGatewayV2(ctx context.Context, e events.APIGatewayV2HTTPRequest) (*http.Request, *events.APIGatewayV2HTTPResponse) {
+	r, err := APIGatewayV2Request(ctx, e)
+	if err != nil {
+		return nil, &events.APIGatewayV2HTTPResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+	}
+	r, w := adapt.Check(lv.v, r)
+	if w != nil {
+		return nil, &events.APIGatewayV2HTTPResponse{
+			StatusCode:        w.StatusCode(),
+			MultiValueHeaders: w.HeaderMap,
+			Body:              w.Body.String(),
+		}
+	}
+	return r, nil
+}
+
+// FunctionURL is like APIGateway, but for events from a Lambda function
+// URL.
+func (lv *Validator) FunctionURL(ctx context.Context, e events.LambdaFunctionURLRequest) (*http.Request, *events.LambdaFunctionURLResponse) {
+	r, err := FunctionURLRequest(ctx, e)
+	if err != nil {
+		return nil, &events.LambdaFunctionURLResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+	}
+	r, w := adapt.Check(lv.v, r)
+	if w != nil {
+		return nil, &events.LambdaFunctionURLResponse{
+			StatusCode: w.StatusCode(),
+			Headers:    w.SingleHeaders(),
+			Body:       w.Body.String(),
+		}
+	}
+	return r, nil
+}
+
+// ALB is like APIGateway, but for events from an Application Load
+// Balancer target group. The response uses MultiValueHeaders, so if the
+// target group doesn't have multi-value headers enabled, set its Headers
+// from them before returning it.
+func (lv *Validator) ALB(ctx context.Context, e events.ALBTargetGroupRequest) (*http.Request, *events.ALBTargetGroupResponse) {
+	r, err := ALBRequest(ctx, e)
+	if err != nil {
+		return nil, &events.ALBTargetGroupResponse{StatusCode: http.StatusBadRequest, StatusDescription: "400 Bad Request", Body: err.Error()}
+	}
+	r, w := adapt.Check(lv.v, r)
+	if w != nil {
+		return nil, &events.ALBTargetGroupResponse{
+			StatusCode:        w.StatusCode(),
+			StatusDescription: fmt.Sprintf("%d %s", w.StatusCode(), http.StatusText(w.StatusCode())),
+			MultiValueHeaders: w.HeaderMap,
+			Body:              w.Body.String(),
+		}
+	}
+	return r, nil
+}
+
+// APIGatewayRequest converts an API Gateway REST API event into an
+// *http.Request with the URL the client requested, including the stage
+// name when the API was called through its execute-api domain.
+//
+// REST API events carry the query string already decoded, so it is
+// re-encoded with its parameters in alphabetical order. A webhook URL with
+// several query parameters only validates if they are in that order; HTTP
+// APIs, whose events carry the raw query string, don't have this problem.
+func APIGatewayRequest(ctx context.Context, e events.APIGatewayProxyRequest) (*http.Request, error) {
+	header := headers(e.Headers, e.MultiValueHeaders)
+	query := make(url.Values)
+	for k, v := range e.QueryStringParameters {
+		query.Set(k, v)
+	}
+	for k, vs := range e.MultiValueQueryStringParameters {
+		query[k] = vs
+	}
+	path := e.RequestContext.Path // includes the stage, unlike e.Path
+	if path == "" {
+		path = e.Path
+	}
+	host := e.RequestContext.DomainName
+	if host == "" {
+		host = header.Get("Host")
+	}
+	return newRequest(ctx, e.HTTPMethod, host, path, query.Encode(), header, e.Body, e.IsBase64Encoded)
+}
+
+// APIGatewayV2Request converts an API Gateway HTTP API event into an
+// *http.Request with the URL the client requested.
+func APIGatewayV2Request(ctx context.Context, e events.APIGatewayV2HTTPRequest) (*http.Request, error) {
+	header := headers(e.Headers, nil)
+	if len(e.Cookies) > 0 {
+		header.Set("Cookie", strings.Join(e.Cookies, "; "))
+	}
+	host := e.RequestContext.DomainName
+	if host == "" {
+		host = header.Get("Host")
+	}
+	return newRequest(ctx, e.RequestContext.HTTP.Method, host, e.RawPath, e.RawQueryString, header, e.Body, e.IsBase64Encoded)
+}
+
+// FunctionURLRequest converts a Lambda function URL event into an
+// *http.Request for the function URL's own domain. If the function sits
+// behind a CDN such as CloudFront, configure the public URL with
+// twilio.WithBaseURL.
+func FunctionURLRequest(ctx context.Context, e events.LambdaFunctionURLRequest) (*http.Request, error) {
+	header := headers(e.Headers, nil)
+	if len(e.Cookies) > 0 {
+		header.Set("Cookie", strings.Join(e.Cookies, "; "))
+	}
+	host := e.RequestContext.DomainName
+	if host == "" {
+		host = header.Get("Host")
+	}
+	return newRequest(ctx, e.RequestContext.HTTP.Method, host, e.RawPath, e.RawQueryString, header, e.Body, e.IsBase64Encoded)
+}
+
+// ALBRequest converts an Application Load Balancer target group event into
+// an *http.Request. The host comes from the Host header and the scheme from
+// X-Forwarded-Proto, both of which the load balancer sets.
+//
+// ALB events carry query parameters as the client sent them, still
+// percent-encoded, but not their order, so as with APIGatewayRequest they
+// are put in alphabetical order.
+func ALBRequest(ctx context.Context, e events.ALBTargetGroupRequest) (*http.Request, error) {
+	header := headers(e.Headers, e.MultiValueHeaders)
+	query := e.MultiValueQueryStringParameters
+	if query == nil {
+		query = make(map[string][]string, len(e.QueryStringParameters))
+		for k, v := range e.QueryStringParameters {
+			query[k] = []string{v}
+		}
+	}
+	var pairs []string
+	for k, vs := range query {
+		for _, v := range vs {
+			pairs = append(pairs, k+"="+v)
+		}
+	}
+	slices.Sort(pairs)
+	return newRequest(ctx, e.HTTPMethod, header.Get("Host"), e.Path, strings.Join(pairs, "&"), header, e.Body, e.IsBase64Encoded)
+}
+
+// headers merges the single- and multi-value headers of an event.
+func headers(single map[string]string, multi map[string][]string) http.Header {
+	header := make(http.Header, len(single))
+	for k, v := range single {
+		header.Set(k, v)
+	}
+	for k, vs := range multi {
+		header.Del(k)
+		for _, v := range vs {
+			header.Add(k, v)
+		}
+	}
+	return header
+}
+
+// newRequest builds a request for https://host/path?rawQuery, or another
+// scheme if X-Forwarded-Proto says so.
+func newRequest(ctx context.Context, method, host, path, rawQuery string, header http.Header, body string, isBase64 bool) (*http.Request, error) {
+	b := []byte(body)
+	if isBase64 {
+		var err error
+		if b, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, err
+		}
+	}
+	target := path
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	return adapt.NewRequest(ctx, method, adapt.Scheme(header, "https")+"://"+host+target, header, b)
+}
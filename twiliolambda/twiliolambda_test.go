@@ -0,0 +1,135 @@
+package twiliolambda_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiliolambda"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+var form = url.Values{"From": {"+14158675309"}, "Body": {"hi"}}
+
+func TestAPIGateway(t *testing.T) {
+	signed := "https://abc123.execute-api.us-east-1.amazonaws.com/prod/sms?a=1&b=2"
+	e := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/sms",
+		Headers: map[string]string{
+			"content-type":           "application/x-www-form-urlencoded",
+			"x-twilio-signature-256": twilio.ComputeSignatureSHA256([]byte("12345"), signed, form),
+		},
+		MultiValueQueryStringParameters: map[string][]string{"b": {"2"}, "a": {"1"}},
+		Body:                            base64.StdEncoding.EncodeToString([]byte(form.Encode())),
+		IsBase64Encoded:                 true,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			DomainName: "abc123.execute-api.us-east-1.amazonaws.com",
+			Path:       "/prod/sms",
+		},
+	}
+	v := twiliolambda.New("12345")
+	r, failed := v.APIGateway(context.Background(), e)
+	if failed != nil {
+		t.Fatalf("got failure response %d: %s", failed.StatusCode, failed.Body)
+	}
+	res, ok := twilio.FromContext(r.Context())
+	if !ok || res.Params.Get("From") != "+14158675309" {
+		t.Errorf("got result %+v", res)
+	}
+	if msg, err := webhook.DecodeMessage(r); err != nil || msg.Body != "hi" {
+		t.Errorf("DecodeMessage: got %+v, %v", msg, err)
+	}
+
+	e.Headers["x-twilio-signature-256"] = twilio.ComputeSignatureSHA256([]byte("55555"), signed, form)
+	r, failed = v.APIGateway(context.Background(), e)
+	if r != nil || failed == nil || failed.StatusCode != http.StatusForbidden {
+		t.Errorf("bad signature: got request %v, response %+v; want 403", r, failed)
+	}
+}
+
+func TestAPIGatewayV2(t *testing.T) {
+	signed := "https://hooks.example.com/sms?b=2&a=1"
+	e := events.APIGatewayV2HTTPRequest{
+		RawPath:        "/sms",
+		RawQueryString: "b=2&a=1",
+		Headers: map[string]string{
+			"content-type":           "application/x-www-form-urlencoded",
+			"x-twilio-signature-256": twilio.ComputeSignatureSHA256([]byte("12345"), signed, form),
+		},
+		Body: form.Encode(),
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			DomainName: "hooks.example.com",
+			HTTP:       events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: "POST"},
+		},
+	}
+	v := twiliolambda.New("12345")
+	if _, failed := v.APIGatewayV2(context.Background(), e); failed != nil {
+		t.Fatalf("got failure response %d: %s", failed.StatusCode, failed.Body)
+	}
+
+	e.Body += "&Extra=1"
+	if _, failed := v.APIGatewayV2(context.Background(), e); failed == nil || failed.StatusCode != http.StatusForbidden {
+		t.Errorf("tampered body: got %+v, want 403", failed)
+	}
+}
+
+func TestFunctionURL(t *testing.T) {
+	signed := "https://abc.lambda-url.us-east-1.on.aws/sms?x=%7E1"
+	e := events.LambdaFunctionURLRequest{
+		RawPath:        "/sms",
+		RawQueryString: "x=%7E1",
+		Headers: map[string]string{
+			"content-type":           "application/x-www-form-urlencoded",
+			"x-twilio-signature-256": twilio.ComputeSignatureSHA256([]byte("12345"), signed, form),
+		},
+		Body: form.Encode(),
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			DomainName: "abc.lambda-url.us-east-1.on.aws",
+			HTTP:       events.LambdaFunctionURLRequestContextHTTPDescription{Method: "POST"},
+		},
+	}
+	v := twiliolambda.New("12345", twilio.RawCanonicalization())
+	if _, failed := v.FunctionURL(context.Background(), e); failed != nil {
+		t.Fatalf("got failure response %d: %s", failed.StatusCode, failed.Body)
+	}
+
+	e.Headers["x-twilio-signature-256"] = twilio.ComputeSignatureSHA256([]byte("55555"), signed, form)
+	if _, failed := v.FunctionURL(context.Background(), e); failed == nil || failed.StatusCode != http.StatusForbidden {
+		t.Errorf("bad signature: got %+v, want 403", failed)
+	}
+}
+
+func TestALB(t *testing.T) {
+	signed := "https://hooks.example.com/sms?a=%2B1&b=2"
+	e := events.ALBTargetGroupRequest{
+		HTTPMethod:            "POST",
+		Path:                  "/sms",
+		QueryStringParameters: map[string]string{"b": "2", "a": "%2B1"},
+		Headers: map[string]string{
+			"host":                   "hooks.example.com",
+			"x-forwarded-proto":      "https",
+			"content-type":           "application/x-www-form-urlencoded",
+			"x-twilio-signature-256": twilio.ComputeSignatureSHA256([]byte("12345"), signed, form),
+		},
+		Body: form.Encode(),
+	}
+	v := twiliolambda.New("12345")
+	r, failed := v.ALB(context.Background(), e)
+	if failed != nil {
+		t.Fatalf("got failure response %d: %s", failed.StatusCode, failed.Body)
+	}
+	if res, _ := twilio.FromContext(r.Context()); res.Params.Get("a") != "+1" {
+		t.Errorf("query parameter a = %q, want +1", res.Params.Get("a"))
+	}
+
+	e.Headers["host"] = "evil.example"
+	if _, failed := v.ALB(context.Background(), e); failed == nil || failed.StatusCode != http.StatusForbidden {
+		t.Errorf("wrong host: got %+v, want 403", failed)
+	}
+}
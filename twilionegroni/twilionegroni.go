@@ -0,0 +1,41 @@
+// Package twilionegroni adapts twilio.Validator to negroni.
+package twilionegroni
+
+import (
+	"net/http"
+
+	"github.com/urfave/negroni"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// A Handler is a negroni.Handler that passes on only requests that pass
+// validation by its twilio.Validator. Other requests get the Validator's
+// failure response, 403 Forbidden by default.
+//
+// Example usage:
+//
+//	n := negroni.Classic()
+//	n.Use(twilionegroni.New(myAuthToken, twilio.TrustForwardedHeaders()))
+//	n.UseHandler(mux)
+type Handler struct {
+	v *twilio.Validator
+}
+
+var _ negroni.Handler = (*Handler)(nil)
+
+// New returns a Handler that validates requests with a twilio.Validator
+// configured with opts.
+func New(twilioAuthToken string, opts ...twilio.Option) *Handler {
+	return FromValidator(twilio.New(twilioAuthToken, opts...))
+}
+
+// FromValidator returns a Handler that validates requests with v.
+func FromValidator(v *twilio.Validator) *Handler {
+	return &Handler{v: v}
+}
+
+// ServeHTTP implements negroni.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	h.v.Middleware(next).ServeHTTP(w, r)
+}
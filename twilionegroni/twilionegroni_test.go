@@ -0,0 +1,41 @@
+package twilionegroni_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/negroni"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twilionegroni"
+)
+
+func TestHandler(t *testing.T) {
+	var reached bool
+	n := negroni.New(twilionegroni.New("12345"))
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := twilio.FromContext(r.Context())
+		reached = res != nil && res.Valid
+	})
+
+	for _, test := range []struct {
+		key  string
+		code int
+	}{
+		{"12345", http.StatusOK},
+		{"55555", http.StatusForbidden},
+	} {
+		reached = false
+		r := httptest.NewRequest("GET", "/sms", nil)
+		r.Header.Set(twilio.Signature256Header, twilio.ComputeSignatureSHA256([]byte(test.key), "http://example.com/sms", nil))
+		w := httptest.NewRecorder()
+		n.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("signed with %s: got %d, want %d", test.key, w.Code, test.code)
+		}
+		if reached != (test.code == http.StatusOK) {
+			t.Errorf("signed with %s: next handler reached = %v", test.key, reached)
+		}
+	}
+}
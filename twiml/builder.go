@@ -0,0 +1,76 @@
+package twiml
+
+// The methods below build a Response one verb at a time, for the common
+// linear flows that don't need a verb's other attributes. Each appends a
+// verb and returns the Response, so calls can be chained. A nil Response
+// is allocated on first use.
+//
+// Example usage:
+//
+//	resp := new(twiml.Response).Say("Please hold.").Pause(1).Redirect("/next")
+//
+// For verbs with more attributes, or nested nouns and prompts, use Append
+// with the verb's struct:
+//
+//	resp := new(twiml.Response).
+//		Say("Connecting you now.").
+//		Append(&twiml.Dial{CallerID: "+14155550199", Number: "+14155550100"})
+
+// Append appends verbs to r and returns it.
+func (r *Response) Append(verbs ...Verb) *Response {
+	if r == nil {
+		r = &Response{}
+	}
+	r.Verbs = append(r.Verbs, verbs...)
+	return r
+}
+
+// Say appends a Say that reads text aloud.
+func (r *Response) Say(text string) *Response {
+	return r.Append(&Say{Text: text})
+}
+
+// Play appends a Play that plays the audio file at url.
+func (r *Response) Play(url string) *Response {
+	return r.Append(&Play{URL: url})
+}
+
+// Pause appends a Pause of the given number of seconds.
+func (r *Response) Pause(seconds int) *Response {
+	return r.Append(&Pause{Length: seconds})
+}
+
+// Dial appends a Dial that calls number.
+func (r *Response) Dial(number string) *Response {
+	return r.Append(&Dial{Number: number})
+}
+
+// Enqueue appends an Enqueue that puts the caller in the named queue.
+func (r *Response) Enqueue(name string) *Response {
+	return r.Append(&Enqueue{Name: name})
+}
+
+// Leave appends a Leave.
+func (r *Response) Leave() *Response {
+	return r.Append(&Leave{})
+}
+
+// Redirect appends a Redirect to url.
+func (r *Response) Redirect(url string) *Response {
+	return r.Append(&Redirect{URL: url})
+}
+
+// Hangup appends a Hangup.
+func (r *Response) Hangup() *Response {
+	return r.Append(&Hangup{})
+}
+
+// Reject appends a Reject with the default reason.
+func (r *Response) Reject() *Response {
+	return r.Append(&Reject{})
+}
+
+// Message appends a Message that replies with body.
+func (r *Response) Message(body string) *Response {
+	return r.Append(&Message{Body: body})
+}
@@ -0,0 +1,32 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestBuilder(t *testing.T) {
+	resp := new(twiml.Response).
+		Say("Please hold.").
+		Pause(1).
+		Play("hold.mp3").
+		Append(&twiml.Dial{CallerID: "+14155550199", Number: "+14155550100"}).
+		Dial("+14155550101").
+		Enqueue("support").
+		Leave().
+		Redirect("/next").
+		Reject().
+		Hangup()
+	want := `<Response><Say>Please hold.</Say><Pause length="1"></Pause><Play>hold.mp3</Play>` +
+		`<Dial callerId="+14155550199">+14155550100</Dial><Dial>+14155550101</Dial>` +
+		`<Enqueue>support</Enqueue><Leave></Leave><Redirect>/next</Redirect><Reject></Reject><Hangup></Hangup></Response>`
+	if got := marshal(t, resp); got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+
+	var nilResp *twiml.Response
+	if got := marshal(t, nilResp.Message("Thanks!")); got != `<Response><Message><Body>Thanks!</Body></Message></Response>` {
+		t.Errorf("nil Response: got %s", got)
+	}
+}
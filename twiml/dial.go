@@ -0,0 +1,179 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// A Noun is a party Dial can connect to: *Number, *Client, *Sip,
+// *Conference or *Queue. Dialing several Numbers, Clients or Sips rings
+// them all at once and connects the first to answer.
+//
+// Example usage:
+//
+//	&twiml.Dial{Nouns: []twiml.Noun{
+//		&twiml.Number{Number: "+14155550100", URL: "/whisper"},
+//		&twiml.Client{Identity: "alice"},
+//	}}
+type Noun interface {
+	noun()
+}
+
+// Number dials a phone number.
+type Number struct {
+	XMLName              xml.Name         `xml:"Number"`
+	Number               string           `xml:",chardata"`
+	SendDigits           string           `xml:"sendDigits,attr,omitempty"`
+	URL                  string           `xml:"url,attr,omitempty"` // whisper TwiML played to the called party
+	Method               Method           `xml:"method,attr,omitempty"`
+	StatusCallbackEvent  CallEvents       `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallback       string           `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod Method           `xml:"statusCallbackMethod,attr,omitempty"`
+	MachineDetection     MachineDetection `xml:"machineDetection,attr,omitempty"`
+	BYOC                 string           `xml:"byoc,attr,omitempty"`
+}
+
+// Client dials a Voice SDK client by its identity.
+type Client struct {
+	XMLName              xml.Name   `xml:"Client"`
+	Identity             string     `xml:",chardata"`
+	URL                  string     `xml:"url,attr,omitempty"`
+	Method               Method     `xml:"method,attr,omitempty"`
+	StatusCallbackEvent  CallEvents `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallback       string     `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod Method     `xml:"statusCallbackMethod,attr,omitempty"`
+}
+
+// Sip dials a SIP URI.
+type Sip struct {
+	XMLName              xml.Name         `xml:"Sip"`
+	URI                  string           `xml:",chardata"`
+	Username             string           `xml:"username,attr,omitempty"`
+	Password             string           `xml:"password,attr,omitempty"`
+	URL                  string           `xml:"url,attr,omitempty"`
+	Method               Method           `xml:"method,attr,omitempty"`
+	StatusCallbackEvent  CallEvents       `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallback       string           `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod Method           `xml:"statusCallbackMethod,attr,omitempty"`
+	MachineDetection     MachineDetection `xml:"machineDetection,attr,omitempty"`
+}
+
+// Conference connects the caller to the conference room named Name.
+type Conference struct {
+	XMLName                xml.Name         `xml:"Conference"`
+	Name                   string           `xml:",chardata"`
+	Muted                  bool             `xml:"muted,attr,omitempty"`
+	Beep                   Beep             `xml:"beep,attr,omitempty"`
+	StartConferenceOnEnter *bool            `xml:"startConferenceOnEnter,attr,omitempty"`
+	EndConferenceOnExit    bool             `xml:"endConferenceOnExit,attr,omitempty"`
+	WaitURL                string           `xml:"waitUrl,attr,omitempty"`
+	WaitMethod             Method           `xml:"waitMethod,attr,omitempty"`
+	MaxParticipants        int              `xml:"maxParticipants,attr,omitempty"`
+	Record                 string           `xml:"record,attr,omitempty"`
+	Region                 string           `xml:"region,attr,omitempty"`
+	Coach                  string           `xml:"coach,attr,omitempty"`
+	ParticipantLabel       string           `xml:"participantLabel,attr,omitempty"`
+	StatusCallbackEvent    ConferenceEvents `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallback         string           `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod   Method           `xml:"statusCallbackMethod,attr,omitempty"`
+}
+
+// Queue connects the caller to the first caller waiting in the queue named
+// Name. URL is TwiML played to the dequeued caller before they are
+// connected. With TaskRouter, ReservationSid names the reservation being
+// accepted, and PostWorkActivitySid the activity the worker moves to when
+// the call ends.
+type Queue struct {
+	XMLName             xml.Name `xml:"Queue"`
+	Name                string   `xml:",chardata"`
+	URL                 string   `xml:"url,attr,omitempty"`
+	Method              Method   `xml:"method,attr,omitempty"`
+	ReservationSid      string   `xml:"reservationSid,attr,omitempty"`
+	PostWorkActivitySid string   `xml:"postWorkActivitySid,attr,omitempty"`
+}
+
+func (*Number) noun()     {}
+func (*Client) noun()     {}
+func (*Sip) noun()        {}
+func (*Conference) noun() {}
+func (*Queue) noun()      {}
+
+// A CallEvent is a change in the state of a dialed call that Twilio can
+// report to a status callback.
+type CallEvent string
+
+const (
+	CallInitiated CallEvent = "initiated"
+	CallRinging   CallEvent = "ringing"
+	CallAnswered  CallEvent = "answered"
+	CallCompleted CallEvent = "completed"
+)
+
+// CallEvents are the events reported to a status callback. Without them,
+// only CallCompleted is reported.
+type CallEvents []CallEvent
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (e CallEvents) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return spaceList(name, e), nil
+}
+
+// A ConferenceEvent is a change in a conference that Twilio can report to
+// a status callback.
+type ConferenceEvent string
+
+const (
+	ConferenceStart        ConferenceEvent = "start"
+	ConferenceEnd          ConferenceEvent = "end"
+	ConferenceJoin         ConferenceEvent = "join"
+	ConferenceLeave        ConferenceEvent = "leave"
+	ConferenceMute         ConferenceEvent = "mute"
+	ConferenceHold         ConferenceEvent = "hold"
+	ConferenceModify       ConferenceEvent = "modify"
+	ConferenceSpeaker      ConferenceEvent = "speaker"
+	ConferenceAnnouncement ConferenceEvent = "announcement"
+)
+
+// ConferenceEvents are the events reported to a conference's status
+// callback.
+type ConferenceEvents []ConferenceEvent
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (e ConferenceEvents) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return spaceList(name, e), nil
+}
+
+// spaceList returns an attribute whose value is the space-separated list
+// of values, or no attribute if there are none.
+func spaceList[T ~string](name xml.Name, values []T) xml.Attr {
+	if len(values) == 0 {
+		return xml.Attr{}
+	}
+	var b strings.Builder
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(v))
+	}
+	return xml.Attr{Name: name, Value: b.String()}
+}
+
+// MachineDetection asks Twilio to detect whether a person or an answering
+// machine picked up a dialed call.
+type MachineDetection string
+
+const (
+	DetectMachine    MachineDetection = "Enable"
+	DetectMessageEnd MachineDetection = "DetectMessageEnd"
+)
+
+// Beep is when a conference plays a beep as participants come and go.
+type Beep string
+
+const (
+	BeepAlways  Beep = "true"
+	BeepNever   Beep = "false"
+	BeepOnEnter Beep = "onEnter"
+	BeepOnExit  Beep = "onExit"
+)
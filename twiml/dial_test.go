@@ -0,0 +1,45 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestDialNouns(t *testing.T) {
+	tests := []struct {
+		noun twiml.Noun
+		want string
+	}{
+		{
+			&twiml.Number{
+				Number:              "+14155550100",
+				SendDigits:          "wwww1928",
+				URL:                 "/whisper",
+				StatusCallbackEvent: twiml.CallEvents{twiml.CallRinging, twiml.CallAnswered},
+				StatusCallback:      "/status",
+				MachineDetection:    twiml.DetectMachine,
+			},
+			`<Number sendDigits="wwww1928" url="/whisper" statusCallbackEvent="ringing answered" statusCallback="/status" machineDetection="Enable">+14155550100</Number>`,
+		},
+		{&twiml.Client{Identity: "alice", URL: "/whisper"}, `<Client url="/whisper">alice</Client>`},
+		{&twiml.Sip{URI: "sip:bob@example.com", Username: "bob", Password: "secret"}, `<Sip username="bob" password="secret">sip:bob@example.com</Sip>`},
+		{
+			&twiml.Conference{
+				Name:                   "standup",
+				Beep:                   twiml.BeepOnEnter,
+				StartConferenceOnEnter: twiml.Bool(false),
+				StatusCallbackEvent:    twiml.ConferenceEvents{twiml.ConferenceStart, twiml.ConferenceJoin},
+			},
+			`<Conference beep="onEnter" startConferenceOnEnter="false" statusCallbackEvent="start join">standup</Conference>`,
+		},
+		{&twiml.Queue{Name: "support", URL: "/about-to-connect"}, `<Queue url="/about-to-connect">support</Queue>`},
+		{&twiml.Queue{ReservationSid: "WR123", PostWorkActivitySid: "WA123"}, `<Queue reservationSid="WR123" postWorkActivitySid="WA123"></Queue>`},
+	}
+	for _, test := range tests {
+		got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Dial{Nouns: []twiml.Noun{test.noun}}}})
+		if want := "<Response><Dial>" + test.want + "</Dial></Response>"; got != want {
+			t.Errorf("got %s\nwant %s", got, want)
+		}
+	}
+}
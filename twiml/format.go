@@ -0,0 +1,208 @@
+package twiml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// Indent returns the XML of resp with each element that contains only
+// other elements broken over lines and indented by indent, for logs and
+// debugging. The content of elements with text in them, such as Say, is
+// left exactly as it is, since spacing there can change what Twilio says.
+func Indent(resp *Response, indent string) ([]byte, error) {
+	b, err := Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return IndentXML(b, indent)
+}
+
+// Compact returns the XML of resp with empty elements self-closed, for
+// responses where size matters.
+func Compact(resp *Response) ([]byte, error) {
+	b, err := Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return CompactXML(b)
+}
+
+// IndentXML is like Indent, but reformats the TwiML document data, which
+// need not have been produced by this package. Comments are dropped.
+func IndentXML(data []byte, indent string) ([]byte, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	root.write(&b, indent, 0)
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// CompactXML is like Compact, but reformats the TwiML document data,
+// removing the spacing between elements that contain only other elements.
+// Comments are dropped.
+func CompactXML(data []byte) ([]byte, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	root.write(&b, "", -1)
+	return b.Bytes(), nil
+}
+
+// A tree is an element of a document being reformatted. Its content is
+// made up of *tree and xml.CharData values.
+type tree struct {
+	start   xml.StartElement
+	content []any
+}
+
+// parseTree returns the root element of data. Names keep the prefixes
+// they have in data.
+func parseTree(data []byte) (*tree, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*tree
+	var root *tree
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			t := &tree{start: tok.Copy()}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.content = append(parent.content, t)
+			} else if root == nil {
+				root = t
+			}
+			stack = append(stack, t)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].start.Name != tok.Name {
+				return nil, &xml.SyntaxError{Msg: "unexpected end element </" + rawName(tok.Name) + ">"}
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.content = append(parent.content, tok.Copy())
+			}
+		}
+	}
+	if root == nil || len(stack) > 0 {
+		return nil, &xml.SyntaxError{Msg: "document is incomplete"}
+	}
+	return root, nil
+}
+
+// hasText reports whether t contains text other than spacing, or no
+// elements at all, in which case its content is written as is.
+func (t *tree) hasText() bool {
+	elems := false
+	for _, c := range t.content {
+		switch c := c.(type) {
+		case xml.CharData:
+			if len(bytes.TrimSpace(c)) > 0 {
+				return true
+			}
+		case *tree:
+			elems = true
+		}
+	}
+	return !elems
+}
+
+// write writes t to b, indented to depth, or compactly if depth is
+// negative.
+func (t *tree) write(b *bytes.Buffer, indent string, depth int) {
+	b.WriteByte('<')
+	b.WriteString(rawName(t.start.Name))
+	for _, a := range t.start.Attr {
+		b.WriteByte(' ')
+		b.WriteString(rawName(a.Name))
+		b.WriteString(`="`)
+		escape(b, a.Value, true)
+		b.WriteByte('"')
+	}
+	if len(t.content) == 0 {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteByte('>')
+	if t.hasText() {
+		for _, c := range t.content {
+			switch c := c.(type) {
+			case xml.CharData:
+				escape(b, string(c), false)
+			case *tree:
+				c.write(b, "", -1)
+			}
+		}
+	} else {
+		wrote := false
+		for _, c := range t.content {
+			c, ok := c.(*tree)
+			if !ok {
+				continue
+			}
+			if depth >= 0 {
+				b.WriteByte('\n')
+				b.WriteString(strings.Repeat(indent, depth+1))
+				c.write(b, indent, depth+1)
+			} else {
+				c.write(b, "", -1)
+			}
+			wrote = true
+		}
+		if depth >= 0 && wrote {
+			b.WriteByte('\n')
+			b.WriteString(strings.Repeat(indent, depth))
+		}
+	}
+	b.WriteString("</")
+	b.WriteString(rawName(t.start.Name))
+	b.WriteByte('>')
+}
+
+func rawName(n xml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+// escape writes s to b, escaped for use as text or, if attr is set, as an
+// attribute value in double quotes.
+func escape(b *bytes.Buffer, s string, attr bool) {
+	for _, r := range s {
+		switch {
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case attr && r == '"':
+			b.WriteString("&#34;")
+		case attr && r == '\n':
+			b.WriteString("&#xA;")
+		case attr && r == '\t':
+			b.WriteString("&#x9;")
+		case r == '\r':
+			b.WriteString("&#xD;")
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestIndent(t *testing.T) {
+	resp := &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Gather{Action: "/menu", Prompts: []twiml.Prompt{
+			&twiml.Say{Text: "Press ", SSML: []twiml.SSML{&twiml.Emphasis{SSML: []twiml.SSML{twiml.Text("one")}}, twiml.Text(" & go")}},
+			&twiml.Pause{},
+		}},
+		&twiml.Hangup{},
+	}}
+	got, err := twiml.Indent(resp, "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+  <Gather action="/menu">
+    <Say>Press <emphasis>one</emphasis> &amp; go</Say>
+    <Pause/>
+  </Gather>
+  <Hangup/>
+</Response>
+`
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// Compacting undoes indenting, and vice versa.
+	compact, err := twiml.CompactXML(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCompact := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<Response><Gather action="/menu"><Say>Press <emphasis>one</emphasis> &amp; go</Say><Pause/></Gather><Hangup/></Response>`
+	if string(compact) != wantCompact {
+		t.Errorf("got\n%s\nwant\n%s", compact, wantCompact)
+	}
+	if again, _ := twiml.IndentXML(compact, "  "); string(again) != want {
+		t.Errorf("re-indented:\n%s\nwant\n%s", again, want)
+	}
+	if c, _ := twiml.Compact(resp); string(c) != wantCompact {
+		t.Errorf("Compact:\n%s\nwant\n%s", c, wantCompact)
+	}
+}
+
+func TestFormatXML(t *testing.T) {
+	const doc = `<Response>
+	<!-- greeting -->
+	<Say voice="a&quot;b">  Hi  </Say>
+	<Say><lang xml:lang="fr-FR">Bonjour</lang></Say>
+</Response>`
+	got, err := twiml.CompactXML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<Response><Say voice="a&#34;b">  Hi  </Say><Say><lang xml:lang="fr-FR">Bonjour</lang></Say></Response>`
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	for _, bad := range []string{"", "<Response>", "<Response></Say>"} {
+		if _, err := twiml.CompactXML([]byte(bad)); err == nil {
+			t.Errorf("CompactXML(%q): got nil error", bad)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package twiml
+
+import (
+	"net/http"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// A HandlerFunc handles a webhook request by returning the TwiML to
+// respond with. A nil Response is sent as an empty one, which tells Twilio
+// there is nothing more to do.
+type HandlerFunc func(r *http.Request) (*Response, error)
+
+// A HandlerOption configures a Handler.
+type HandlerOption func(*handler)
+
+type handler struct {
+	fallback *Response
+	onError  func(r *http.Request, err error)
+}
+
+// WithFallback makes the Handler respond with resp, rather than 500
+// Internal Server Error, when its HandlerFunc returns an error. Use it to
+// apologize to the caller instead of letting Twilio play its application
+// error message.
+//
+// Example usage:
+//
+//	sorry := &twiml.Response{Verbs: []twiml.Verb{
+//		&twiml.Say{Text: "Sorry, something went wrong. Please call back later."},
+//		&twiml.Hangup{},
+//	}}
+//	h := twiml.Handler(v, voice, twiml.WithFallback(sorry))
+func WithFallback(resp *Response) HandlerOption {
+	return func(h *handler) { h.fallback = resp }
+}
+
+// OnError registers a function that is called with the error whenever the
+// Handler's HandlerFunc returns one, before the fallback is sent. It is
+// intended for logging.
+func OnError(f func(r *http.Request, err error)) HandlerOption {
+	return func(h *handler) { h.onError = f }
+}
+
+// Handler returns an http.Handler that validates requests with v, calls f
+// for genuine Twilio requests, and writes the Response f returns. Requests
+// that fail validation get v's failure response.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken)
+//	http.Handle("/voice", twiml.Handler(v, func(r *http.Request) (*twiml.Response, error) {
+//		return &twiml.Response{Verbs: []twiml.Verb{&twiml.Say{Text: "Hello!"}}}, nil
+//	}))
+func Handler(v *twilio.Validator, f HandlerFunc, opts ...HandlerOption) http.Handler {
+	h := &handler{}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := f(r)
+		if err != nil {
+			if h.onError != nil {
+				h.onError(r, err)
+			}
+			if h.fallback == nil {
+				http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			resp = h.fallback
+		}
+		if resp == nil {
+			resp = &Response{}
+		}
+		Write(w, resp)
+	}))
+}
@@ -0,0 +1,66 @@
+package twiml_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func signedRequest(token string) *http.Request {
+	form := url.Values{"From": {"+14158675309"}}
+	r := httptest.NewRequest("POST", "https://example.com/voice", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte(token), "https://example.com/voice", form))
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	v := twilio.New("12345")
+	fail := errors.New("database down")
+	var logged error
+	h := twiml.Handler(v, func(r *http.Request) (*twiml.Response, error) {
+		switch r.PostFormValue("From") {
+		case "+14158675309":
+			return &twiml.Response{Verbs: []twiml.Verb{&twiml.Hangup{}}}, nil
+		}
+		return nil, fail
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("12345"))
+	if !strings.HasSuffix(w.Body.String(), "<Response><Hangup></Hangup></Response>") {
+		t.Errorf("valid request: got %d %q", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("55555"))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("bad signature: got status %d, want 403", w.Code)
+	}
+
+	returnsError := func(*http.Request) (*twiml.Response, error) { return nil, fail }
+	w = httptest.NewRecorder()
+	twiml.Handler(v, returnsError).ServeHTTP(w, signedRequest("12345"))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("handler error: got status %d, want 500", w.Code)
+	}
+
+	sorry := &twiml.Response{Verbs: []twiml.Verb{&twiml.Say{Text: "Sorry."}}}
+	w = httptest.NewRecorder()
+	twiml.Handler(v, returnsError,
+		twiml.WithFallback(sorry),
+		twiml.OnError(func(r *http.Request, err error) { logged = err }),
+	).ServeHTTP(w, signedRequest("12345"))
+	if w.Code != http.StatusOK || !strings.HasSuffix(w.Body.String(), "<Say>Sorry.</Say></Response>") {
+		t.Errorf("handler error with fallback: got %d %q", w.Code, w.Body)
+	}
+	if logged != fail {
+		t.Errorf("OnError got %v, want %v", logged, fail)
+	}
+}
@@ -0,0 +1,246 @@
+package twiml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Responses can also be decoded from JSON, for call flows kept in
+// configuration rather than code. A Response is a list of verbs, each an
+// object with a single key naming the verb, whose value holds the verb's
+// fields:
+//
+//	[
+//		{"Say": {"Text": "Hello!", "Voice": "alice"}},
+//		{"Gather": {"Action": "/menu", "NumDigits": 1, "Prompts": [{"Say": "Press 1 for sales."}]}},
+//		{"Redirect": "/start"}
+//	]
+//
+// Names match case-insensitively, so the XML attribute names, such as
+// numDigits, work as well as the Go field names. Verbs and nouns with
+// content, such as Say, Play, Redirect and Number, may be given as just a
+// string, and so may Text in SSML lists.
+
+// contentFields names the field that holds the content of each element
+// that can be given as a string in JSON.
+var contentFields = map[string]string{
+	"Say":        "Text",
+	"Play":       "URL",
+	"Redirect":   "URL",
+	"Dial":       "Number",
+	"Enqueue":    "Name",
+	"Message":    "Body",
+	"Number":     "Number",
+	"Client":     "Identity",
+	"Sip":        "URI",
+	"Conference": "Name",
+	"Queue":      "Name",
+	"phoneme":    "Text",
+	"say-as":     "Text",
+	"sub":        "Text",
+	"w":          "Text",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Response) UnmarshalJSON(b []byte) error {
+	var list []json.RawMessage
+	if err := json.Unmarshal(b, &list); err != nil {
+		return fmt.Errorf("twiml: a Response must be a list of verbs: %w", err)
+	}
+	var err error
+	r.Verbs, err = decodeJSONList(list, verbTypes, "Response")
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (g *Gather) UnmarshalJSON(b []byte) error {
+	type fields Gather
+	return decodeJSONParent(b, (*fields)(g), "Prompts", promptTypes, &g.Prompts, "Gather")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PayPrompt) UnmarshalJSON(b []byte) error {
+	type fields PayPrompt
+	return decodeJSONParent(b, (*fields)(p), "Prompts", promptTypes, &p.Prompts, "Prompt")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Dial) UnmarshalJSON(b []byte) error {
+	type fields Dial
+	return decodeJSONParent(b, (*fields)(d), "Nouns", nounTypes, &d.Nouns, "Dial")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Say) UnmarshalJSON(b []byte) error {
+	type fields Say
+	return decodeJSONSSMLParent(b, (*fields)(s), &s.SSML)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Emphasis) UnmarshalJSON(b []byte) error {
+	type fields Emphasis
+	return decodeJSONSSMLParent(b, (*fields)(e), &e.SSML)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *Lang) UnmarshalJSON(b []byte) error {
+	type fields Lang
+	return decodeJSONSSMLParent(b, (*fields)(l), &l.SSML)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *P) UnmarshalJSON(b []byte) error {
+	type fields P
+	return decodeJSONSSMLParent(b, (*fields)(p), &p.SSML)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *S) UnmarshalJSON(b []byte) error {
+	type fields S
+	return decodeJSONSSMLParent(b, (*fields)(s), &s.SSML)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Prosody) UnmarshalJSON(b []byte) error {
+	type fields Prosody
+	return decodeJSONSSMLParent(b, (*fields)(p), &p.SSML)
+}
+
+// decodeJSONParent decodes the object b into fields, a pointer to a struct
+// type without an UnmarshalJSON method, and its member key into list, as
+// with decodeJSONList.
+func decodeJSONParent[T any](b []byte, fields any, key string, types map[string]func() T, list *[]T, parent string) error {
+	children, err := decodeJSONFields(b, fields, key)
+	if err != nil {
+		return err
+	}
+	*list, err = decodeJSONList(children, types, parent)
+	return err
+}
+
+// decodeJSONSSMLParent is like decodeJSONParent, for an SSML element whose
+// content is in its SSML member.
+func decodeJSONSSMLParent(b []byte, fields any, list *[]SSML) error {
+	children, err := decodeJSONFields(b, fields, "SSML")
+	if err != nil {
+		return err
+	}
+	*list, err = decodeJSONSSML(children)
+	return err
+}
+
+// decodeJSONFields decodes the object b into fields, except for its member
+// key, a list of child elements, which it returns undecoded. Like
+// encoding/json, it matches key case-insensitively.
+func decodeJSONFields(b []byte, fields any, key string) ([]json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	var children []json.RawMessage
+	for k, v := range obj {
+		if !strings.EqualFold(k, key) {
+			continue
+		}
+		if err := json.Unmarshal(v, &children); err != nil {
+			return nil, fmt.Errorf("twiml: %s must be a list: %w", key, err)
+		}
+		delete(obj, k)
+	}
+	rest, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return children, json.Unmarshal(rest, fields)
+}
+
+// decodeJSONSSML decodes a list of SSML elements, in which strings stand
+// for Text.
+func decodeJSONSSML(list []json.RawMessage) ([]SSML, error) {
+	var elems []SSML
+	for _, raw := range list {
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			elems = append(elems, Text(text))
+			continue
+		}
+		elem, err := decodeJSONList([]json.RawMessage{raw}, ssmlTypes, "SSML")
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem...)
+	}
+	return elems, nil
+}
+
+// decodeJSONList decodes each element of list as the type named by its
+// single key, looked up in types.
+func decodeJSONList[T any](list []json.RawMessage, types map[string]func() T, parent string) ([]T, error) {
+	var elems []T
+	for _, raw := range list {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil || len(obj) != 1 {
+			return nil, fmt.Errorf("twiml: each element of a %s must be an object with a single key, the element name; got %s", parent, raw)
+		}
+		for key, value := range obj {
+			name, newElem := lookupType(types, key)
+			if newElem == nil {
+				return nil, fmt.Errorf("twiml: %s can't contain %s", parent, key)
+			}
+			elem := newElem()
+			value = bytes.TrimSpace(value)
+			switch {
+			case bytes.Equal(value, []byte("null")):
+			case len(value) > 0 && value[0] == '"':
+				field, ok := contentFields[name]
+				if !ok {
+					return nil, fmt.Errorf("twiml: %s must be given as an object", name)
+				}
+				value = []byte(`{` + strconv.Quote(field) + `:` + string(value) + `}`)
+				fallthrough
+			default:
+				if err := json.Unmarshal(value, elem); err != nil {
+					return nil, fmt.Errorf("twiml: %s: %w", name, err)
+				}
+			}
+			elems = append(elems, elem)
+		}
+	}
+	return elems, nil
+}
+
+// lookupType finds name in types, ignoring case.
+func lookupType[T any](types map[string]func() T, name string) (string, func() T) {
+	if f, ok := types[name]; ok {
+		return name, f
+	}
+	for k, f := range types {
+		if strings.EqualFold(k, name) {
+			return k, f
+		}
+	}
+	return "", nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides a number, Loop can be
+// given as "forever".
+func (l *Loop) UnmarshalJSON(b []byte) error {
+	if string(b) == `"forever"` {
+		*l = LoopForever
+		return nil
+	}
+	return json.Unmarshal(b, (*int)(l))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides a number of seconds,
+// SpeechTimeout can be given as "auto".
+func (t *SpeechTimeout) UnmarshalJSON(b []byte) error {
+	if string(b) == `"auto"` {
+		*t = SpeechTimeoutAuto
+		return nil
+	}
+	return json.Unmarshal(b, (*int)(t))
+}
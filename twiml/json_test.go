@@ -0,0 +1,88 @@
+package twiml_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	doc := `[
+		{"Say": {"voice": "Polly.Joanna", "loop": 2, "text": "Hi. ", "ssml": [
+			{"break": {"time": "1s"}},
+			"Your code is ",
+			{"say-as": {"interpretAs": "characters", "text": "A1"}},
+			{"prosody": {"rate": "slow", "ssml": [{"emphasis": {"ssml": ["R&D"]}}]}}
+		]}},
+		{"Gather": {"input": "dtmf speech", "action": "/next", "speechTimeout": "auto", "hints": ["sales", "support"],
+			"prompts": [{"Play": {"url": "menu.mp3", "loop": "forever"}}, {"Pause": {"length": 2}}]}},
+		{"Dial": {"callerId": "+14155550199", "nouns": [
+			{"Number": {"number": "+14155550100", "statusCallbackEvent": ["ringing", "answered"]}},
+			{"Client": "alice"}
+		]}},
+		{"Dial": "+14155550100"},
+		{"Pay": {"prompts": [{"for": "payment-card-number", "attempt": [1, 2], "prompts": [{"Say": "Card number?"}]}]}},
+		{"Message": {"body": "Thanks", "media": ["https://example.com/a.jpg"]}},
+		{"redirect": "/next"},
+		{"Hangup": null},
+		{"Reject": {}}
+	]`
+	var got twiml.Response
+	if err := json.Unmarshal([]byte(doc), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Say{Voice: "Polly.Joanna", Loop: 2, Text: "Hi. ", SSML: []twiml.SSML{
+			&twiml.Break{Time: "1s"},
+			twiml.Text("Your code is "),
+			&twiml.SayAs{InterpretAs: "characters", Text: "A1"},
+			&twiml.Prosody{Rate: "slow", SSML: []twiml.SSML{&twiml.Emphasis{SSML: []twiml.SSML{twiml.Text("R&D")}}}},
+		}},
+		&twiml.Gather{
+			Input:         twiml.InputDTMFSpeech,
+			Action:        "/next",
+			SpeechTimeout: twiml.SpeechTimeoutAuto,
+			Hints:         twiml.Hints{"sales", "support"},
+			Prompts:       []twiml.Prompt{&twiml.Play{URL: "menu.mp3", Loop: twiml.LoopForever}, &twiml.Pause{Length: 2}},
+		},
+		&twiml.Dial{CallerID: "+14155550199", Nouns: []twiml.Noun{
+			&twiml.Number{Number: "+14155550100", StatusCallbackEvent: twiml.CallEvents{twiml.CallRinging, twiml.CallAnswered}},
+			&twiml.Client{Identity: "alice"},
+		}},
+		&twiml.Dial{Number: "+14155550100"},
+		&twiml.Pay{Prompts: []twiml.PayPrompt{{
+			For:     twiml.PaymentCardNumber,
+			Attempt: twiml.Attempts{1, 2},
+			Prompts: []twiml.Prompt{&twiml.Say{Text: "Card number?"}},
+		}}},
+		&twiml.Message{Body: "Thanks", Media: []string{"https://example.com/a.jpg"}},
+		&twiml.Redirect{URL: "/next"},
+		&twiml.Hangup{},
+		&twiml.Reject{},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", marshal(t, &got), marshal(t, &want))
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	for _, tc := range []struct{ doc, want string }{
+		{`{"Say": "hi"}`, "must be a list of verbs"},
+		{`[{"Say": "hi", "Play": "a.mp3"}]`, "single key"},
+		{`[{"Number": "+14155550100"}]`, "Response can't contain Number"},
+		{`[{"Gather": {"prompts": [{"Dial": "+14155550100"}]}}]`, "Gather can't contain Dial"},
+		{`[{"Dial": {"nouns": ["+14155550100"]}}]`, "single key"},
+		{`[{"Hangup": "now"}]`, "Hangup must be given as an object"},
+		{`[{"Gather": {"numDigits": "one"}}]`, "Gather"},
+		{`[{"Gather": {"prompts": {"Say": "hi"}}}]`, "Prompts must be a list"},
+	} {
+		var resp twiml.Response
+		err := json.Unmarshal([]byte(tc.doc), &resp)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want one containing %q", tc.doc, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package twiml
+
+import "encoding/xml"
+
+// Message replies to an incoming message with a message of its own, or
+// sends one to To. Media holds the URLs of up to MaxMedia images or other
+// files to attach, which makes the message an MMS, or a WhatsApp message
+// with media. Twilio requests StatusCallback as the message's delivery
+// status changes. Redirect can also be used in messaging responses.
+//
+// Example usage:
+//
+//	resp := &twiml.Response{Verbs: []twiml.Verb{
+//		&twiml.Message{
+//			Body:           "Here's your receipt.",
+//			Media:          []string{receiptURL, mapURL},
+//			StatusCallback: "/sms/status",
+//		},
+//	}}
+type Message struct {
+	XMLName        xml.Name `xml:"Message"`
+	To             string   `xml:"to,attr,omitempty"`
+	From           string   `xml:"from,attr,omitempty"`
+	Action         string   `xml:"action,attr,omitempty"`
+	Method         Method   `xml:"method,attr,omitempty"`
+	StatusCallback string   `xml:"statusCallback,attr,omitempty"`
+	Body           string   `xml:"Body,omitempty"`
+	Media          []string `xml:"Media"`
+}
+
+// MaxMedia is the most Media a Message can have.
+const MaxMedia = 10
+
+func (*Message) verb() {}
@@ -0,0 +1,31 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestMessage(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Message{Body: "Thanks!"},
+		&twiml.Message{Body: "Photo:", Media: []string{"https://example.com/a.jpg"}},
+		&twiml.Message{
+			To:             "whatsapp:+14155550100",
+			From:           "whatsapp:+14155550199",
+			StatusCallback: "/status",
+			Media:          []string{"https://example.com/a.jpg", "https://example.com/b.pdf"},
+		},
+		&twiml.Redirect{URL: "/more"},
+	}})
+	want := "<Response>" +
+		"<Message><Body>Thanks!</Body></Message>" +
+		"<Message><Body>Photo:</Body><Media>https://example.com/a.jpg</Media></Message>" +
+		`<Message to="whatsapp:+14155550100" from="whatsapp:+14155550199" statusCallback="/status">` +
+		"<Media>https://example.com/a.jpg</Media><Media>https://example.com/b.pdf</Media></Message>" +
+		"<Redirect>/more</Redirect>" +
+		"</Response>"
+	if got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+}
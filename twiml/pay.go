@@ -0,0 +1,148 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// Pay collects payment details from the caller by DTMF, outside the
+// application's PCI scope, and sends them to the payment processor
+// configured as PaymentConnector. Twilio then requests Action with the
+// outcome in the Result parameter (see PayResult) and, on success, a
+// PaymentConfirmationCode or PaymentToken.
+//
+// Example usage:
+//
+//	&twiml.Pay{
+//		Action:        "/paid",
+//		ChargeAmount:  "10.00",
+//		Currency:      "usd",
+//		PaymentMethod: twiml.CreditCard,
+//		Prompts: []twiml.PayPrompt{{
+//			For:     twiml.PaymentCardNumber,
+//			Prompts: []twiml.Prompt{&twiml.Say{Text: "Please enter your card number."}},
+//		}},
+//	}
+type Pay struct {
+	XMLName              xml.Name      `xml:"Pay"`
+	Input                Input         `xml:"input,attr,omitempty"`
+	Action               string        `xml:"action,attr,omitempty"`
+	BankAccountType      string        `xml:"bankAccountType,attr,omitempty"`
+	ChargeAmount         string        `xml:"chargeAmount,attr,omitempty"` // a decimal such as "10.00"; empty to tokenize only
+	Currency             string        `xml:"currency,attr,omitempty"`
+	Description          string        `xml:"description,attr,omitempty"`
+	Language             Language      `xml:"language,attr,omitempty"`
+	MaxAttempts          int           `xml:"maxAttempts,attr,omitempty"`
+	MinPostalCodeLength  int           `xml:"minPostalCodeLength,attr,omitempty"`
+	PaymentConnector     string        `xml:"paymentConnector,attr,omitempty"`
+	PaymentMethod        PaymentMethod `xml:"paymentMethod,attr,omitempty"`
+	PostalCode           string        `xml:"postalCode,attr,omitempty"` // "false", or the postal code if already known
+	SecurityCode         *bool         `xml:"securityCode,attr,omitempty"`
+	Timeout              int           `xml:"timeout,attr,omitempty"`
+	TokenType            TokenType     `xml:"tokenType,attr,omitempty"`
+	ValidCardTypes       CardTypes     `xml:"validCardTypes,attr,omitempty"`
+	StatusCallback       string        `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod Method        `xml:"statusCallbackMethod,attr,omitempty"`
+	Prompts              []PayPrompt   `xml:"Prompt"`
+	Parameters           []Parameter   `xml:"Parameter"`
+}
+
+// A PayPrompt replaces what Pay says when it asks for one of the payment
+// details, optionally only for certain attempts, card types or errors.
+type PayPrompt struct {
+	XMLName               xml.Name    `xml:"Prompt"`
+	For                   PaymentStep `xml:"for,attr,omitempty"`
+	Attempt               Attempts    `xml:"attempt,attr,omitempty"`
+	CardType              CardTypes   `xml:"cardType,attr,omitempty"`
+	ErrorType             ErrorTypes  `xml:"errorType,attr,omitempty"`
+	RequireMatchingInputs bool        `xml:"requireMatchingInputs,attr,omitempty"`
+	Prompts               []Prompt
+}
+
+// A PaymentMethod is the kind of payment Pay collects.
+type PaymentMethod string
+
+const (
+	CreditCard PaymentMethod = "credit-card"
+	ACHDebit   PaymentMethod = "ach-debit"
+)
+
+// A TokenType is the kind of token Pay asks the payment processor for when
+// ChargeAmount is empty.
+type TokenType string
+
+const (
+	OneTimeToken       TokenType = "one-time"
+	ReusableToken      TokenType = "reusable"
+	PaymentMethodToken TokenType = "payment-method"
+)
+
+// A PaymentStep is a payment detail that Pay asks the caller for.
+type PaymentStep string
+
+const (
+	PaymentCardNumber PaymentStep = "payment-card-number"
+	ExpirationDate    PaymentStep = "expiration-date"
+	SecurityCodeStep  PaymentStep = "security-code"
+	PostalCodeStep    PaymentStep = "postal-code"
+	BankRoutingNumber PaymentStep = "bank-routing-number"
+	BankAccountNumber PaymentStep = "bank-account-number"
+	PaymentProcessing PaymentStep = "payment-processing"
+)
+
+// CardTypes are card brands, such as "visa" and "amex".
+type CardTypes []string
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (c CardTypes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return spaceList(name, c), nil
+}
+
+// ErrorTypes are the errors a PayPrompt is for, such as
+// "invalid-card-number" and "timeout".
+type ErrorTypes []string
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (e ErrorTypes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return spaceList(name, e), nil
+}
+
+// Attempts are the attempts, counting from 1, that a PayPrompt is for.
+type Attempts []int
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (a Attempts) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	s := make([]string, len(a))
+	for i, n := range a {
+		s[i] = strconv.Itoa(n)
+	}
+	return spaceList(name, s), nil
+}
+
+// A PayResult is the outcome of Pay, as sent to its Action in the Result
+// parameter.
+type PayResult string
+
+const (
+	PaySuccess               PayResult = "success"
+	PayTooManyFailedAttempts PayResult = "too-many-failed-attempts"
+	PayCallerInterrupted     PayResult = "caller-interrupted-with-star"
+	PayCallerHungUp          PayResult = "caller-hung-up"
+	PayValidationError       PayResult = "validation-error"
+	PayInternalError         PayResult = "internal-error"
+)
+
+// Names of the parameters Twilio sends to the Action of Pay. Card details
+// are masked except for their last four digits.
+const (
+	ParamResult                  = "Result"
+	ParamPaymentConfirmationCode = "PaymentConfirmationCode"
+	ParamPaymentToken            = "PaymentToken"
+	ParamPaymentCardNumber       = "PaymentCardNumber"
+	ParamPaymentCardType         = "PaymentCardType"
+	ParamExpirationDate          = "ExpirationDate"
+	ParamPaymentError            = "PaymentError"
+	ParamConnectorError          = "ConnectorError"
+)
+
+func (*Pay) verb() {}
@@ -0,0 +1,32 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestPay(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Pay{
+		Action:           "/paid",
+		ChargeAmount:     "10.00",
+		PaymentConnector: "stripe",
+		PaymentMethod:    twiml.CreditCard,
+		SecurityCode:     twiml.Bool(false),
+		ValidCardTypes:   twiml.CardTypes{"visa", "mastercard"},
+		Prompts: []twiml.PayPrompt{{
+			For:       twiml.PaymentCardNumber,
+			Attempt:   twiml.Attempts{2, 3},
+			ErrorType: twiml.ErrorTypes{"invalid-card-number"},
+			Prompts:   []twiml.Prompt{&twiml.Say{Text: "Try again."}},
+		}},
+		Parameters: []twiml.Parameter{{Name: "order", Value: "17"}},
+	}}})
+	want := `<Response><Pay action="/paid" chargeAmount="10.00" paymentConnector="stripe" paymentMethod="credit-card" securityCode="false" validCardTypes="visa mastercard">` +
+		`<Prompt for="payment-card-number" attempt="2 3" errorType="invalid-card-number"><Say>Try again.</Say></Prompt>` +
+		`<Parameter name="order" value="17"></Parameter>` +
+		`</Pay></Response>`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
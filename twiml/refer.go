@@ -0,0 +1,76 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Refer transfers a call that arrived over SIP, such as from an Elastic SIP
+// Trunk, back to the SIP infrastructure it came from, by sending a SIP
+// REFER to the URI in Sip. When the transfer is done, Twilio requests
+// Action with the ReferCallStatus, ReferSipResponseCode and
+// NotifySipResponseCode parameters.
+//
+// Example usage:
+//
+//	&twiml.Refer{
+//		Action: "/transferred",
+//		Sip:    &twiml.ReferSip{URI: twiml.ReferSipURI("sip:alice@pbx.example.com", map[string]string{"X-Ticket": "42"})},
+//	}
+type Refer struct {
+	XMLName xml.Name `xml:"Refer"`
+	Action  string   `xml:"action,attr,omitempty"`
+	Method  Method   `xml:"method,attr,omitempty"`
+	Sip     *ReferSip
+}
+
+// ReferSip is the SIP URI Refer transfers the call to.
+type ReferSip struct {
+	XMLName xml.Name `xml:"Sip"`
+	URI     string   `xml:",chardata"`
+}
+
+// ReferSipURI returns uri with headers added to it as URI headers, which
+// are sent in the Refer-To header of the REFER and so reach the transfer
+// target. Headers are added in order of name, so the result is stable.
+func ReferSipURI(uri string, headers map[string]string) string {
+	if len(headers) == 0 {
+		return uri
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(uri)
+	sep := byte('?')
+	if strings.Contains(uri, "?") {
+		sep = '&'
+	}
+	for _, name := range names {
+		b.WriteByte(sep)
+		b.WriteString(sipEscape(name))
+		b.WriteByte('=')
+		b.WriteString(sipEscape(headers[name]))
+		sep = '&'
+	}
+	return b.String()
+}
+
+// sipEscape escapes s for use in a SIP URI header, where, unlike in a URL
+// query, a plus sign doesn't stand for a space.
+func sipEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// Names of the parameters Twilio sends to the Action of Refer.
+const (
+	ParamReferCallStatus       = "ReferCallStatus"
+	ParamReferSipResponseCode  = "ReferSipResponseCode"
+	ParamNotifySipResponseCode = "NotifySipResponseCode"
+)
+
+func (*Refer) verb() {}
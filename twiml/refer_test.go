@@ -0,0 +1,34 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestRefer(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Refer{
+		Action: "/transferred",
+		Sip:    &twiml.ReferSip{URI: "sip:alice@pbx.example.com"},
+	}}})
+	if want := `<Response><Refer action="/transferred"><Sip>sip:alice@pbx.example.com</Sip></Refer></Response>`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestReferSipURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		headers map[string]string
+		want    string
+	}{
+		{"sip:alice@example.com", nil, "sip:alice@example.com"},
+		{"sip:alice@example.com", map[string]string{"X-Ticket": "42", "User-to-User": "a b&c"}, "sip:alice@example.com?User-to-User=a%20b%26c&X-Ticket=42"},
+		{"sip:alice@example.com?X-A=1", map[string]string{"X-B": "2"}, "sip:alice@example.com?X-A=1&X-B=2"},
+	}
+	for _, test := range tests {
+		if got := twiml.ReferSipURI(test.uri, test.headers); got != test.want {
+			t.Errorf("ReferSipURI(%q, %v) = %q, want %q", test.uri, test.headers, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package twiml
+
+import "encoding/xml"
+
+// SSML is content of Say: Text, or an SSML element such as *Break or
+// *Prosody. SSML elements nest, and the text within them is escaped when
+// marshaled, so it can contain any characters.
+//
+// Example usage:
+//
+//	&twiml.Say{Voice: "Polly.Joanna", SSML: []twiml.SSML{
+//		twiml.Text("Your code is "),
+//		&twiml.SayAs{InterpretAs: "characters", Text: "A1B2"},
+//		&twiml.Break{Time: "500ms"},
+//		&twiml.Prosody{Rate: "slow", SSML: []twiml.SSML{twiml.Text("Goodbye.")}},
+//	}}
+type SSML interface {
+	ssml()
+}
+
+// Text is plain text in SSML.
+type Text string
+
+// MarshalXML implements xml.Marshaler.
+func (t Text) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return e.EncodeToken(xml.CharData(t))
+}
+
+// Break pauses speech. Strength is one of none, x-weak, weak, medium,
+// strong or x-strong; Time is a duration such as "2s" or "300ms".
+type Break struct {
+	XMLName  xml.Name `xml:"break"`
+	Strength string   `xml:"strength,attr,omitempty"`
+	Time     string   `xml:"time,attr,omitempty"`
+}
+
+// Emphasis speaks its content with emphasis. Level is one of strong,
+// moderate or reduced.
+type Emphasis struct {
+	XMLName xml.Name `xml:"emphasis"`
+	Level   string   `xml:"level,attr,omitempty"`
+	SSML    []SSML
+}
+
+// Lang speaks its content in the language Lang, such as "fr-FR".
+type Lang struct {
+	XMLName xml.Name `xml:"lang"`
+	Lang    string   `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	SSML    []SSML
+}
+
+// P is a paragraph.
+type P struct {
+	XMLName xml.Name `xml:"p"`
+	SSML    []SSML
+}
+
+// S is a sentence.
+type S struct {
+	XMLName xml.Name `xml:"s"`
+	SSML    []SSML
+}
+
+// Phoneme pronounces Text as the phonetic spelling Ph, written in
+// Alphabet, which is ipa or x-sampa.
+type Phoneme struct {
+	XMLName  xml.Name `xml:"phoneme"`
+	Text     string   `xml:",chardata"`
+	Alphabet string   `xml:"alphabet,attr,omitempty"`
+	Ph       string   `xml:"ph,attr"`
+}
+
+// Prosody changes the volume, rate and pitch of its content, with values
+// such as "loud", "slow" or "+10%".
+type Prosody struct {
+	XMLName xml.Name `xml:"prosody"`
+	Volume  string   `xml:"volume,attr,omitempty"`
+	Rate    string   `xml:"rate,attr,omitempty"`
+	Pitch   string   `xml:"pitch,attr,omitempty"`
+	SSML    []SSML
+}
+
+// SayAs says Text as the kind of value InterpretAs names, such as
+// characters, cardinal, date or telephone. Format refines InterpretAs,
+// such as "mdy" for dates.
+type SayAs struct {
+	XMLName     xml.Name `xml:"say-as"`
+	Text        string   `xml:",chardata"`
+	InterpretAs string   `xml:"interpret-as,attr"`
+	Format      string   `xml:"format,attr,omitempty"`
+}
+
+// Sub says Alias in place of Text, such as "World Wide Web Consortium"
+// for "W3C".
+type Sub struct {
+	XMLName xml.Name `xml:"sub"`
+	Text    string   `xml:",chardata"`
+	Alias   string   `xml:"alias,attr"`
+}
+
+// W is a word. Role disambiguates its pronunciation, such as
+// "amazon:VB" to say it as a verb.
+type W struct {
+	XMLName xml.Name `xml:"w"`
+	Text    string   `xml:",chardata"`
+	Role    string   `xml:"role,attr,omitempty"`
+}
+
+func (Text) ssml()      {}
+func (*Break) ssml()    {}
+func (*Emphasis) ssml() {}
+func (*Lang) ssml()     {}
+func (*P) ssml()        {}
+func (*S) ssml()        {}
+func (*Phoneme) ssml()  {}
+func (*Prosody) ssml()  {}
+func (*SayAs) ssml()    {}
+func (*Sub) ssml()      {}
+func (*W) ssml()        {}
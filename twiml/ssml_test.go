@@ -0,0 +1,38 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestSSML(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Say{
+		Voice: "Polly.Joanna",
+		Text:  "Hi. ",
+		SSML: []twiml.SSML{
+			twiml.Text("Your code is "),
+			&twiml.SayAs{InterpretAs: "characters", Text: "A<1>"},
+			&twiml.Break{Time: "500ms"},
+			&twiml.Emphasis{Level: "strong", SSML: []twiml.SSML{
+				&twiml.Prosody{Rate: "slow", SSML: []twiml.SSML{twiml.Text("R&D")}},
+			}},
+			&twiml.Lang{Lang: "fr-FR", SSML: []twiml.SSML{twiml.Text("Merci")}},
+			&twiml.Phoneme{Alphabet: "ipa", Ph: "pɪˈkɑːn", Text: "pecan"},
+			&twiml.Sub{Alias: "World Wide Web Consortium", Text: "W3C"},
+			&twiml.P{SSML: []twiml.SSML{&twiml.S{SSML: []twiml.SSML{&twiml.W{Role: "amazon:VB", Text: "read"}}}}},
+		},
+	}}})
+	want := `<Response><Say voice="Polly.Joanna">Hi. Your code is ` +
+		`<say-as interpret-as="characters">A&lt;1&gt;</say-as>` +
+		`<break time="500ms"></break>` +
+		`<emphasis level="strong"><prosody rate="slow">R&amp;D</prosody></emphasis>` +
+		`<lang xml:lang="fr-FR">Merci</lang>` +
+		`<phoneme alphabet="ipa" ph="pɪˈkɑːn">pecan</phoneme>` +
+		`<sub alias="World Wide Web Consortium">W3C</sub>` +
+		`<p><s><w role="amazon:VB">read</w></s></p>` +
+		`</Say></Response>`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
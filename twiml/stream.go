@@ -0,0 +1,67 @@
+package twiml
+
+import "encoding/xml"
+
+// Connect hands the call over to Stream for the rest of the call: a
+// bidirectional Media Stream, over which the application both hears and
+// speaks to the caller. When the stream ends, Twilio requests Action.
+//
+// Example usage:
+//
+//	&twiml.Connect{Stream: &twiml.Stream{
+//		URL:        "wss://media.example.com/calls",
+//		Parameters: []twiml.Parameter{{Name: "customer", Value: customerID}},
+//	}}
+type Connect struct {
+	XMLName xml.Name `xml:"Connect"`
+	Action  string   `xml:"action,attr,omitempty"`
+	Method  Method   `xml:"method,attr,omitempty"`
+	Stream  *Stream
+}
+
+// Start begins forking the call's audio to Stream, a unidirectional Media
+// Stream, and goes straight on to the next verb.
+type Start struct {
+	XMLName xml.Name `xml:"Start"`
+	Action  string   `xml:"action,attr,omitempty"`
+	Method  Method   `xml:"method,attr,omitempty"`
+	Stream  *Stream
+}
+
+// Stop ends the Media Stream that Start began under the same Name.
+type Stop struct {
+	XMLName xml.Name `xml:"Stop"`
+	Stream  *Stream
+}
+
+// Stream is a Media Stream, which carries the call's audio over a
+// WebSocket connection to URL. Parameters are passed to the application in
+// the stream's start message.
+type Stream struct {
+	XMLName              xml.Name    `xml:"Stream"`
+	URL                  string      `xml:"url,attr,omitempty"`
+	Name                 string      `xml:"name,attr,omitempty"`
+	Track                Track       `xml:"track,attr,omitempty"`
+	StatusCallback       string      `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod Method      `xml:"statusCallbackMethod,attr,omitempty"`
+	Parameters           []Parameter `xml:"Parameter"`
+}
+
+// A Track is the audio of a call that a unidirectional Stream carries.
+type Track string
+
+const (
+	InboundTrack  Track = "inbound_track"
+	OutboundTrack Track = "outbound_track"
+	BothTracks    Track = "both_tracks"
+)
+
+// A Parameter is a custom name and value passed along with a Stream.
+type Parameter struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+func (*Connect) verb() {}
+func (*Start) verb()   {}
+func (*Stop) verb()    {}
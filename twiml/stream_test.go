@@ -0,0 +1,29 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestStream(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Start{Stream: &twiml.Stream{Name: "fork", URL: "wss://example.com/fork", Track: twiml.BothTracks}},
+		&twiml.Connect{Action: "/after", Stream: &twiml.Stream{
+			URL:            "wss://example.com/calls",
+			StatusCallback: "/stream-status",
+			Parameters:     []twiml.Parameter{{Name: "customer", Value: "42"}, {Name: "lang", Value: "en"}},
+		}},
+		&twiml.Stop{Stream: &twiml.Stream{Name: "fork"}},
+	}})
+	want := `<Response>` +
+		`<Start><Stream url="wss://example.com/fork" name="fork" track="both_tracks"></Stream></Start>` +
+		`<Connect action="/after"><Stream url="wss://example.com/calls" statusCallback="/stream-status">` +
+		`<Parameter name="customer" value="42"></Parameter><Parameter name="lang" value="en"></Parameter>` +
+		`</Stream></Connect>` +
+		`<Stop><Stream name="fork"></Stream></Stop>` +
+		`</Response>`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
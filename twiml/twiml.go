@@ -0,0 +1,109 @@
+// Package twiml builds TwiML, the XML documents with which webhook
+// handlers tell Twilio what to do next.
+//
+// A Response holds a list of verbs, each a struct whose fields are the
+// verb's attributes and nested elements. Zero-valued fields are left out,
+// so Twilio applies its defaults.
+//
+// Example usage:
+//
+//	resp := &twiml.Response{Verbs: []twiml.Verb{
+//		&twiml.Say{Text: "Hello from Twilio."},
+//		&twiml.Pause{Length: 1},
+//		&twiml.Redirect{URL: "/next"},
+//	}}
+//	b, err := twiml.Marshal(resp)
+//
+// Simple linear flows can be built by chaining methods on a Response
+// instead:
+//
+//	resp := new(twiml.Response).Say("Hello from Twilio.").Pause(1).Redirect("/next")
+package twiml
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+)
+
+// A Response is a TwiML document.
+type Response struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []Verb
+}
+
+// A Verb is an element that can appear at the top level of a Response. It
+// is implemented by the pointer types of the verbs in this package, such
+// as *Say and *Dial.
+type Verb interface {
+	verb()
+}
+
+// Marshal returns the XML encoding of resp, preceded by an XML
+// declaration.
+func Marshal(resp *Response) ([]byte, error) {
+	b, err := xml.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// Write writes resp to w as the response to a webhook request, with an
+// XML declaration and a Content-Type of text/xml. If resp can't be
+// marshaled, nothing is written to w and Write responds with 500 Internal
+// Server Error instead, so that Twilio plays its application error
+// message, and returns the error.
+//
+// Example usage:
+//
+//	func sms(w http.ResponseWriter, r *http.Request) {
+//		twiml.Write(w, &twiml.Response{Verbs: []twiml.Verb{
+//			&twiml.Message{Body: "Thanks for your message!"},
+//		}})
+//	}
+func Write(w http.ResponseWriter, resp *Response) error {
+	b, err := Marshal(resp)
+	if err != nil {
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	_, err = w.Write(b)
+	return err
+}
+
+// A Method is the HTTP method Twilio uses to request a URL given in an
+// attribute.
+type Method string
+
+const (
+	GET  Method = "GET"
+	POST Method = "POST"
+)
+
+// Loop is the number of times a verb repeats. The zero value leaves the
+// attribute out, so the verb is done once.
+type Loop int
+
+// LoopForever repeats a verb until the call ends or moves on.
+const LoopForever Loop = -1
+
+// MarshalXMLAttr implements xml.MarshalerAttr. Twilio spells LoopForever
+// as loop="0".
+func (l Loop) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	switch {
+	case l == 0:
+		return xml.Attr{}, nil
+	case l < 0:
+		return xml.Attr{Name: name, Value: "0"}, nil
+	}
+	return xml.Attr{Name: name, Value: strconv.Itoa(int(l))}, nil
+}
+
+// Bool returns a pointer to b, for attributes such as Record.PlayBeep that
+// default to true.
+func Bool(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,66 @@
+package twiml_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+// marshal returns the XML for resp without the declaration.
+func marshal(t *testing.T, resp *twiml.Response) string {
+	t.Helper()
+	b, err := twiml.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, ok := strings.CutPrefix(string(b), `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	if !ok {
+		t.Fatalf("output has no XML declaration: %s", b)
+	}
+	return s
+}
+
+func TestMarshal(t *testing.T) {
+	if got, want := marshal(t, &twiml.Response{}), "<Response></Response>"; got != want {
+		t.Errorf("empty response: got %s, want %s", got, want)
+	}
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Say{Text: "Tom & Jerry <3"},
+	}})
+	if want := "<Response><Say>Tom &amp; Jerry &lt;3</Say></Response>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := twiml.Write(w, &twiml.Response{Verbs: []twiml.Verb{&twiml.Hangup{}}}); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/xml" {
+		t.Errorf("Content-Type = %q, want text/xml", ct)
+	}
+	if want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n<Response><Hangup></Hangup></Response>"; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body, want)
+	}
+
+}
+
+func TestLoop(t *testing.T) {
+	tests := []struct {
+		loop twiml.Loop
+		want string
+	}{
+		{0, "<Play>a.mp3</Play>"},
+		{3, `<Play loop="3">a.mp3</Play>`},
+		{twiml.LoopForever, `<Play loop="0">a.mp3</Play>`},
+	}
+	for _, test := range tests {
+		got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Play{URL: "a.mp3", Loop: test.loop}}})
+		if want := "<Response>" + test.want + "</Response>"; got != want {
+			t.Errorf("Loop %d: got %s, want %s", test.loop, got, want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package twimlflow loads simple call flows from YAML or JSON documents,
+// so that menus and messages can be changed without changing code.
+//
+// A flow is a list of verbs in the form twiml.Response decodes from JSON,
+// written in YAML or JSON:
+//
+//	# voice.yaml
+//	- Say: Thanks for calling, {{.CallerName}}.
+//	- Gather:
+//	    action: /menu
+//	    numDigits: 1
+//	    prompts:
+//	      - Say: Press 1 for sales or 2 for support.
+//	- Redirect: /voice
+//
+// Strings may contain text/template placeholders, which are filled in each
+// time the flow is rendered. Placeholders can only appear in fields that
+// hold strings, since a field such as numDigits must be a number before
+// the template is run.
+package twimlflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+// A Flow is a TwiML response loaded from a document, with placeholders to
+// fill in when it is rendered. A Flow is safe for concurrent use.
+type Flow struct {
+	tree any // from yaml, with *template.Template in place of placeholders
+}
+
+// Parse parses a YAML or JSON document holding a single flow. It reports
+// an error if the document isn't valid TwiML in the form twiml.Response
+// decodes from JSON, or if its placeholders aren't valid templates.
+//
+// Example usage:
+//
+//	//go:embed welcome.yaml
+//	var welcomeYAML []byte
+//
+//	var welcome = twimlflow.Must(twimlflow.Parse(welcomeYAML))
+func Parse(data []byte) (*Flow, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("twimlflow: %w", err)
+	}
+	return newFlow("flow", doc)
+}
+
+// Load parses a YAML or JSON document mapping names to flows, for keeping
+// all of an application's flows in one file.
+//
+// Example usage:
+//
+//	welcome:
+//	  - Say: Welcome!
+//	  - Redirect: /menu
+//	menu:
+//	  - Gather: ...
+func Load(data []byte) (map[string]*Flow, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("twimlflow: %w", err)
+	}
+	flows := make(map[string]*Flow, len(doc))
+	for name, tree := range doc {
+		f, err := newFlow(name, tree)
+		if err != nil {
+			return nil, err
+		}
+		flows[name] = f
+	}
+	return flows, nil
+}
+
+func newFlow(name string, doc any) (*Flow, error) {
+	tree, err := compile(name, doc)
+	if err != nil {
+		return nil, err
+	}
+	f := &Flow{tree: tree}
+	// Render once so that mistakes in the document show up when it is
+	// loaded rather than on the first call.
+	if _, err := f.Render(map[string]string{}); err != nil {
+		return nil, fmt.Errorf("twimlflow: %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// Must is a helper that wraps a call to Parse and panics if the error is
+// non-nil.
+func Must(f *Flow, err error) *Flow {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// compile replaces the strings in tree that contain placeholders with
+// templates.
+func compile(name string, tree any) (any, error) {
+	switch t := tree.(type) {
+	case string:
+		if !strings.Contains(t, "{{") {
+			return t, nil
+		}
+		tmpl, err := template.New(name).Option("missingkey=zero").Parse(t)
+		if err != nil {
+			return nil, fmt.Errorf("twimlflow: %w", err)
+		}
+		return tmpl, nil
+	case []any:
+		out := make([]any, len(t))
+		for i, v := range t {
+			var err error
+			if out[i], err = compile(name, v); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, v := range t {
+			var err error
+			if out[k], err = compile(name, v); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return tree, nil
+}
+
+// Render fills in the flow's placeholders from data and returns the
+// resulting Response.
+//
+// Example usage:
+//
+//	resp, err := welcome.Render(map[string]string{"CallerName": name})
+func (f *Flow) Render(data any) (*twiml.Response, error) {
+	tree, err := execute(f.tree, data)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(twiml.Response)
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func execute(tree, data any) (any, error) {
+	switch t := tree.(type) {
+	case *template.Template:
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	case []any:
+		out := make([]any, len(t))
+		for i, v := range t {
+			var err error
+			if out[i], err = execute(v, data); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, v := range t {
+			var err error
+			if out[k], err = execute(v, data); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return tree, nil
+}
+
+// HandlerFunc returns a twiml.HandlerFunc that renders the flow with the
+// request's webhook parameters, so that placeholders such as {{.From}} and
+// {{.Digits}} refer to them. Where a parameter is repeated, the first value
+// is used. Use it with twiml.Handler, which validates the request first.
+//
+// Example usage:
+//
+//	http.Handle("/voice", twiml.Handler(v, flows["welcome"].HandlerFunc()))
+func (f *Flow) HandlerFunc() twiml.HandlerFunc {
+	return func(r *http.Request) (*twiml.Response, error) {
+		data := make(map[string]string)
+		if res, ok := twilio.FromContext(r.Context()); ok {
+			for k, vs := range res.Params {
+				if len(vs) > 0 {
+					data[k] = vs[0]
+				}
+			}
+		}
+		return f.Render(data)
+	}
+}
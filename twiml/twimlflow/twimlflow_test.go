@@ -0,0 +1,92 @@
+package twimlflow_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+	"github.com/jeremyschlatter/twilio-middleware/twiml/twimlflow"
+	"github.com/jeremyschlatter/twilio-middleware/twiml/twimltest"
+)
+
+const flows = `
+welcome:
+  - Say:
+      voice: alice
+      text: Thanks for calling, {{.From}}.
+  - Gather:
+      action: /menu
+      numDigits: 1
+      prompts:
+        - Say: Press 1 for sales or 2 for support.
+  - Redirect: /voice
+menu:
+  - Dial: "{{if eq .Digits \"1\"}}+14155550100{{else}}+14155550101{{end}}"
+`
+
+func TestLoad(t *testing.T) {
+	fs, err := twimlflow.Load([]byte(flows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := fs["welcome"].Render(map[string]string{"From": "+14155550199"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	twimltest.AssertEqual(t, resp, `<Response>
+		<Say voice="alice">Thanks for calling, +14155550199.</Say>
+		<Gather action="/menu" numDigits="1"><Say>Press 1 for sales or 2 for support.</Say></Gather>
+		<Redirect>/voice</Redirect>
+	</Response>`)
+
+	resp, err = fs["menu"].Render(map[string]string{"Digits": "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	twimltest.AssertEqual(t, resp, `<Response><Dial>+14155550101</Dial></Response>`)
+}
+
+func TestParseJSON(t *testing.T) {
+	f, err := twimlflow.Parse([]byte(`[{"Say": "Hello, {{.Name}}!"}, {"Hangup": {}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := f.Render(struct{ Name string }{"Ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	twimltest.AssertEqual(t, resp, `<Response><Say>Hello, Ada!</Say><Hangup/></Response>`)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, doc := range []string{
+		`- Say: [`,
+		`- Say: "{{.From"`,
+		`- Number: "+14155550100"`,
+		`Say: hi`,
+	} {
+		if _, err := twimlflow.Parse([]byte(doc)); err == nil {
+			t.Errorf("%q: Parse succeeded, want an error", doc)
+		}
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	f := twimlflow.Must(twimlflow.Parse([]byte(`- Message: "You said: {{.Body}}"`)))
+	h := twiml.Handler(twilio.New("12345"), f.HandlerFunc())
+
+	form := url.Values{"Body": {"hi"}, "From": {"+14155550199"}}
+	r := httptest.NewRequest("POST", "https://example.com/sms", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), "https://example.com/sms", form))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200", w.Code)
+	}
+	twimltest.AssertEqual(t, w.Body.String(), `<Response><Message><Body>You said: hi</Body></Message></Response>`)
+}
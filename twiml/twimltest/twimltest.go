@@ -0,0 +1,224 @@
+// Package twimltest provides utilities for testing handlers that respond
+// with TwiML.
+//
+// Documents are compared by structure rather than as text, so differences
+// in attribute order, quoting, self-closing tags and indentation don't
+// matter, and failures are reported element by element and attribute by
+// attribute.
+package twimltest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+var update = flag.Bool("twimltest.update", false, "rewrite TwiML golden files with the documents under test")
+
+// Diff returns a description of the differences between the TwiML
+// documents got and want, one per line, or the empty string if they are
+// the same. Each argument can be a *twiml.Response, or the XML of a
+// document as a string or []byte.
+func Diff(got, want any) (string, error) {
+	g, err := parse(got)
+	if err != nil {
+		return "", fmt.Errorf("twimltest: got: %w", err)
+	}
+	w, err := parse(want)
+	if err != nil {
+		return "", fmt.Errorf("twimltest: want: %w", err)
+	}
+	var d differ
+	d.diff(g.Name, g, w)
+	return strings.Join(d.lines, "\n"), nil
+}
+
+// AssertEqual reports an error on t, with the differences, if got and want
+// are not the same document. The arguments are as for Diff.
+//
+// Example usage:
+//
+//	w := httptest.NewRecorder()
+//	handler.ServeHTTP(w, r)
+//	twimltest.AssertEqual(t, w.Body.Bytes(), &twiml.Response{Verbs: []twiml.Verb{
+//		&twiml.Gather{Action: "/next", NumDigits: 1},
+//	}})
+func AssertEqual(t testing.TB, got, want any) {
+	t.Helper()
+	diff, err := Diff(got, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("TwiML differs (-got +want):\n%s", diff)
+	}
+}
+
+// AssertGolden is like AssertEqual, but compares got with the document in
+// the golden file at path. When the test is run with the
+// -twimltest.update flag, it writes got to the file instead.
+//
+// Example usage:
+//
+//	twimltest.AssertGolden(t, w.Body.Bytes(), "testdata/menu.xml")
+func AssertGolden(t testing.TB, got any, path string) {
+	t.Helper()
+	if *update {
+		b, err := marshal(got)
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(path), 0o755)
+		}
+		if err == nil {
+			err = os.WriteFile(path, b, 0o644)
+		}
+		if err != nil {
+			t.Fatalf("twimltest: updating golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("twimltest: %v (run with -twimltest.update to create it)", err)
+	}
+	diff, err := Diff(got, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("TwiML differs from %s (-got +want):\n%s", path, diff)
+	}
+}
+
+// marshal returns the XML of doc, indented for golden files.
+func marshal(doc any) ([]byte, error) {
+	switch doc := doc.(type) {
+	case *twiml.Response:
+		return twiml.Indent(doc, "  ")
+	case string:
+		return []byte(doc), nil
+	case []byte:
+		return doc, nil
+	}
+	return nil, fmt.Errorf("twimltest: can't compare a %T", doc)
+}
+
+// A node is an element of a document.
+type node struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string // all the text directly in the element, with spacing normalized
+	Children []*node
+}
+
+// parse returns the root element of doc.
+func parse(doc any) (*node, error) {
+	b, err := marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	d := xml.NewDecoder(bytes.NewReader(b))
+	var stack []*node
+	var root *node
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &node{Name: name(tok.Name), Attrs: make(map[string]string)}
+			for _, a := range tok.Attr {
+				n.Attrs[name(a.Name)] = a.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.Text = strings.Join(strings.Fields(n.Text), " ")
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += " " + string(tok)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("document is empty")
+	}
+	return root, nil
+}
+
+func name(n xml.Name) string {
+	if n.Space == "http://www.w3.org/XML/1998/namespace" {
+		return "xml:" + n.Local
+	}
+	return n.Local
+}
+
+// A differ collects the differences between two documents.
+type differ struct {
+	lines []string
+}
+
+func (d *differ) add(path, format string, args ...any) {
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (d *differ) diff(path string, got, want *node) {
+	if got.Name != want.Name {
+		d.add(path, "-<%s> +<%s>", got.Name, want.Name)
+		return
+	}
+	keys := make([]string, 0, len(got.Attrs)+len(want.Attrs))
+	for k := range got.Attrs {
+		keys = append(keys, k)
+	}
+	for k := range want.Attrs {
+		if _, ok := got.Attrs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		g, gok := got.Attrs[k]
+		w, wok := want.Attrs[k]
+		switch {
+		case !gok:
+			d.add(path, "+%s=%q", k, w)
+		case !wok:
+			d.add(path, "-%s=%q", k, g)
+		case g != w:
+			d.add(path, "%s: -%q +%q", k, g, w)
+		}
+	}
+	if got.Text != want.Text {
+		d.add(path, "text: -%q +%q", got.Text, want.Text)
+	}
+	for i := 0; i < len(got.Children) || i < len(want.Children); i++ {
+		switch {
+		case i >= len(want.Children):
+			d.add(path, "-<%s> at %d", got.Children[i].Name, i)
+		case i >= len(got.Children):
+			d.add(path, "+<%s> at %d", want.Children[i].Name, i)
+		default:
+			d.diff(fmt.Sprintf("%s/%s[%d]", path, want.Children[i].Name, i), got.Children[i], want.Children[i])
+		}
+	}
+}
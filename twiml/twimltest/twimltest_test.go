@@ -0,0 +1,53 @@
+package twimltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+	"github.com/jeremyschlatter/twilio-middleware/twiml/twimltest"
+)
+
+func menu() *twiml.Response {
+	return &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Gather{Action: "/menu", NumDigits: 1, Prompts: []twiml.Prompt{
+			&twiml.Say{Voice: "alice", Text: "Press 1 for sales."},
+		}},
+		&twiml.Redirect{},
+	}}
+}
+
+func TestDiff(t *testing.T) {
+	// Formatting doesn't matter.
+	same := `<Response><Gather action="/menu" numDigits='1'><Say voice="alice">
+		Press 1 for sales.
+	</Say></Gather><Redirect></Redirect></Response>`
+	if diff, err := twimltest.Diff(menu(), same); err != nil || diff != "" {
+		t.Errorf("equivalent documents: got %q, %v", diff, err)
+	}
+
+	other := `<Response><Gather action="/main" timeout="3"><Say voice="alice">Press 2.</Say></Gather></Response>`
+	diff, err := twimltest.Diff(menu(), other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		`Response/Gather[0]: action: -"/menu" +"/main"`,
+		`Response/Gather[0]: -numDigits="1"`,
+		`Response/Gather[0]: +timeout="3"`,
+		`Response/Gather[0]/Say[0]: text: -"Press 1 for sales." +"Press 2."`,
+		`Response: -<Redirect> at 1`,
+	}, "\n")
+	if diff != want {
+		t.Errorf("got diff\n%s\nwant\n%s", diff, want)
+	}
+
+	if _, err := twimltest.Diff(menu(), "<Response>"); err == nil {
+		t.Error("malformed XML: got nil error")
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	twimltest.AssertGolden(t, menu(), "testdata/menu.xml")
+	twimltest.AssertEqual(t, menu(), []byte(`<Response><Gather action="/menu" numDigits="1"><Say voice="alice">Press 1 for sales.</Say></Gather><Redirect/></Response>`))
+}
@@ -0,0 +1,352 @@
+package twiml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal parses the TwiML document data into resp. Verbs and nouns are
+// decoded into the types of this package, so tests can inspect a Response
+// field by field rather than comparing XML text.
+//
+// Example usage:
+//
+//	var resp twiml.Response
+//	if err := twiml.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+//		t.Fatal(err)
+//	}
+//	if g, ok := resp.Verbs[0].(*twiml.Gather); !ok || g.Action != "/next" {
+//		t.Errorf("want a Gather with action /next, got %#v", resp.Verbs[0])
+//	}
+func Unmarshal(data []byte, resp *Response) error {
+	return xml.Unmarshal(data, resp)
+}
+
+// The types of the elements that can appear in each kind of list, by
+// element name.
+var (
+	verbTypes = map[string]func() Verb{
+		"Say":      func() Verb { return new(Say) },
+		"Play":     func() Verb { return new(Play) },
+		"Pause":    func() Verb { return new(Pause) },
+		"Gather":   func() Verb { return new(Gather) },
+		"Dial":     func() Verb { return new(Dial) },
+		"Record":   func() Verb { return new(Record) },
+		"Redirect": func() Verb { return new(Redirect) },
+		"Hangup":   func() Verb { return new(Hangup) },
+		"Reject":   func() Verb { return new(Reject) },
+		"Enqueue":  func() Verb { return new(Enqueue) },
+		"Leave":    func() Verb { return new(Leave) },
+		"Message":  func() Verb { return new(Message) },
+		"Connect":  func() Verb { return new(Connect) },
+		"Start":    func() Verb { return new(Start) },
+		"Stop":     func() Verb { return new(Stop) },
+		"Pay":      func() Verb { return new(Pay) },
+		"Refer":    func() Verb { return new(Refer) },
+	}
+	promptTypes = map[string]func() Prompt{
+		"Say":   func() Prompt { return new(Say) },
+		"Play":  func() Prompt { return new(Play) },
+		"Pause": func() Prompt { return new(Pause) },
+	}
+	nounTypes = map[string]func() Noun{
+		"Number":     func() Noun { return new(Number) },
+		"Client":     func() Noun { return new(Client) },
+		"Sip":        func() Noun { return new(Sip) },
+		"Conference": func() Noun { return new(Conference) },
+		"Queue":      func() Noun { return new(Queue) },
+	}
+	ssmlTypes = map[string]func() SSML{
+		"break":    func() SSML { return new(Break) },
+		"emphasis": func() SSML { return new(Emphasis) },
+		"lang":     func() SSML { return new(Lang) },
+		"p":        func() SSML { return new(P) },
+		"s":        func() SSML { return new(S) },
+		"phoneme":  func() SSML { return new(Phoneme) },
+		"prosody":  func() SSML { return new(Prosody) },
+		"say-as":   func() SSML { return new(SayAs) },
+		"sub":      func() SSML { return new(Sub) },
+		"w":        func() SSML { return new(W) },
+	}
+)
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (r *Response) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if start.Name.Local != "Response" {
+		return fmt.Errorf("twiml: document is a <%s>, not a <Response>", start.Name.Local)
+	}
+	r.XMLName = start.Name
+	return decodeList(d, start, verbTypes, &r.Verbs, nil)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (g *Gather) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Gather
+	if err := decodeAttrs(start, (*attrs)(g)); err != nil {
+		return err
+	}
+	return decodeList(d, start, promptTypes, &g.Prompts, nil)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (p *PayPrompt) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs PayPrompt
+	if err := decodeAttrs(start, (*attrs)(p)); err != nil {
+		return err
+	}
+	return decodeList(d, start, promptTypes, &p.Prompts, nil)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (dl *Dial) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Dial
+	if err := decodeAttrs(start, (*attrs)(dl)); err != nil {
+		return err
+	}
+	err := decodeList(d, start, nounTypes, &dl.Nouns, func(text xml.CharData) {
+		dl.Number += string(text)
+	})
+	dl.Number = strings.TrimSpace(dl.Number)
+	return err
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Text directly inside the
+// element, as in <Message>Hello</Message>, goes in Body.
+func (m *Message) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Message
+	if err := decodeAttrs(start, (*attrs)(m)); err != nil {
+		return err
+	}
+	var text string
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			var s string
+			if err := d.DecodeElement(&s, &tok); err != nil {
+				return err
+			}
+			switch tok.Name.Local {
+			case "Body":
+				m.Body += s
+			case "Media":
+				m.Media = append(m.Media, s)
+			default:
+				return fmt.Errorf("twiml: <Message> can't contain <%s>", tok.Name.Local)
+			}
+		case xml.CharData:
+			text += string(tok)
+		case xml.EndElement:
+			if m.Body == "" {
+				m.Body = strings.TrimSpace(text)
+			}
+			return nil
+		}
+	}
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Text before the first SSML
+// element goes in Text, and any after it in SSML.
+func (s *Say) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Say
+	if err := decodeAttrs(start, (*attrs)(s)); err != nil {
+		return err
+	}
+	return decodeList(d, start, ssmlTypes, &s.SSML, func(text xml.CharData) {
+		if len(s.SSML) == 0 {
+			s.Text += string(text)
+		} else {
+			s.SSML = appendText(s.SSML, text)
+		}
+	})
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (e *Emphasis) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Emphasis
+	return decodeSSML(d, start, (*attrs)(e), &e.SSML)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (l *Lang) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Lang
+	return decodeSSML(d, start, (*attrs)(l), &l.SSML)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (p *P) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs P
+	return decodeSSML(d, start, (*attrs)(p), &p.SSML)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (s *S) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs S
+	return decodeSSML(d, start, (*attrs)(s), &s.SSML)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (p *Prosody) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type attrs Prosody
+	return decodeSSML(d, start, (*attrs)(p), &p.SSML)
+}
+
+// decodeSSML decodes an SSML element whose content is a list of SSML.
+func decodeSSML(d *xml.Decoder, start xml.StartElement, attrs any, list *[]SSML) error {
+	if err := decodeAttrs(start, attrs); err != nil {
+		return err
+	}
+	return decodeList(d, start, ssmlTypes, list, func(text xml.CharData) {
+		*list = appendText(*list, text)
+	})
+}
+
+// appendText appends text to list, joining it to any Text at the end.
+func appendText(list []SSML, text xml.CharData) []SSML {
+	if n := len(list); n > 0 {
+		if t, ok := list[n-1].(Text); ok {
+			list[n-1] = t + Text(text)
+			return list
+		}
+	}
+	return append(list, Text(text))
+}
+
+// decodeAttrs decodes the attributes of start into v, a pointer to a
+// struct type without an UnmarshalXML method.
+func decodeAttrs(start xml.StartElement, v any) error {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	e.EncodeToken(start)
+	e.EncodeToken(start.End())
+	if err := e.Flush(); err != nil {
+		return err
+	}
+	return xml.Unmarshal(buf.Bytes(), v)
+}
+
+// decodeList decodes the content of the element start, appending each
+// child element to list as the type given for its name in types. Text is
+// passed to text, or ignored if text is nil.
+func decodeList[T any](d *xml.Decoder, start xml.StartElement, types map[string]func() T, list *[]T, text func(xml.CharData)) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			newElem, ok := types[tok.Name.Local]
+			if !ok {
+				return fmt.Errorf("twiml: <%s> can't contain <%s>", start.Name.Local, tok.Name.Local)
+			}
+			elem := newElem()
+			if err := d.DecodeElement(elem, &tok); err != nil {
+				return err
+			}
+			*list = append(*list, elem)
+		case xml.CharData:
+			if text != nil {
+				text(tok)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (l *Loop) UnmarshalXMLAttr(attr xml.Attr) error {
+	n, err := strconv.Atoi(attr.Value)
+	if err != nil {
+		return fmt.Errorf("twiml: bad %s: %w", attr.Name.Local, err)
+	}
+	*l = Loop(n)
+	if n == 0 {
+		*l = LoopForever
+	}
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (t *SpeechTimeout) UnmarshalXMLAttr(attr xml.Attr) error {
+	if attr.Value == "auto" {
+		*t = SpeechTimeoutAuto
+		return nil
+	}
+	n, err := strconv.Atoi(attr.Value)
+	if err != nil {
+		return fmt.Errorf("twiml: bad %s: %w", attr.Name.Local, err)
+	}
+	*t = SpeechTimeout(n)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (h *Hints) UnmarshalXMLAttr(attr xml.Attr) error {
+	*h = nil
+	for _, hint := range strings.Split(attr.Value, ",") {
+		if hint = strings.TrimSpace(hint); hint != "" {
+			*h = append(*h, hint)
+		}
+	}
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (e *CallEvents) UnmarshalXMLAttr(attr xml.Attr) error {
+	*e = splitList[CallEvent](attr.Value)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (e *ConferenceEvents) UnmarshalXMLAttr(attr xml.Attr) error {
+	*e = splitList[ConferenceEvent](attr.Value)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (e *RecordingEvents) UnmarshalXMLAttr(attr xml.Attr) error {
+	*e = splitList[RecordingEvent](attr.Value)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (c *CardTypes) UnmarshalXMLAttr(attr xml.Attr) error {
+	*c = splitList[string](attr.Value)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (e *ErrorTypes) UnmarshalXMLAttr(attr xml.Attr) error {
+	*e = splitList[string](attr.Value)
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (a *Attempts) UnmarshalXMLAttr(attr xml.Attr) error {
+	*a = nil
+	for _, f := range strings.Fields(attr.Value) {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return fmt.Errorf("twiml: bad %s: %w", attr.Name.Local, err)
+		}
+		*a = append(*a, n)
+	}
+	return nil
+}
+
+// splitList splits a space-separated attribute value.
+func splitList[T ~string](value string) []T {
+	var list []T
+	for _, f := range strings.Fields(value) {
+		list = append(list, T(f))
+	}
+	return list
+}
@@ -0,0 +1,143 @@
+package twiml_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+// everything uses every element and attribute type in the package.
+func everything() *twiml.Response {
+	return &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Say{Voice: "Polly.Joanna", Loop: 2, Text: "Hi. ", SSML: []twiml.SSML{
+			&twiml.Break{Time: "1s"},
+			twiml.Text("Your code is "),
+			&twiml.SayAs{InterpretAs: "characters", Text: "A<1>"},
+			&twiml.Prosody{Rate: "slow", SSML: []twiml.SSML{&twiml.Emphasis{SSML: []twiml.SSML{twiml.Text("R&D")}}}},
+			&twiml.Lang{Lang: "fr-FR", SSML: []twiml.SSML{&twiml.P{SSML: []twiml.SSML{&twiml.S{SSML: []twiml.SSML{twiml.Text("Merci")}}}}}},
+			&twiml.Phoneme{Alphabet: "ipa", Ph: "pɪˈkɑːn", Text: "pecan"},
+			&twiml.Sub{Alias: "World Wide Web Consortium", Text: "W3C"},
+			&twiml.W{Role: "amazon:VB", Text: "read"},
+		}},
+		&twiml.Gather{
+			Input:         twiml.InputDTMFSpeech,
+			Action:        "/next",
+			SpeechTimeout: twiml.SpeechTimeoutAuto,
+			Hints:         twiml.Hints{"sales", "support"},
+			Prompts:       []twiml.Prompt{&twiml.Play{URL: "menu.mp3", Loop: twiml.LoopForever}, &twiml.Pause{Length: 2}},
+		},
+		&twiml.Dial{CallerID: "+14155550199", Nouns: []twiml.Noun{
+			&twiml.Number{Number: "+14155550100", StatusCallbackEvent: twiml.CallEvents{twiml.CallRinging, twiml.CallAnswered}},
+			&twiml.Client{Identity: "alice"},
+			&twiml.Sip{URI: "sip:bob@example.com"},
+		}},
+		&twiml.Dial{Number: "+14155550100"},
+		&twiml.Dial{Nouns: []twiml.Noun{
+			&twiml.Conference{Name: "standup", StartConferenceOnEnter: twiml.Bool(false), StatusCallbackEvent: twiml.ConferenceEvents{twiml.ConferenceJoin}},
+		}},
+		&twiml.Dial{Nouns: []twiml.Noun{&twiml.Queue{Name: "support"}}},
+		&twiml.Record{PlayBeep: twiml.Bool(true), RecordingStatusCallbackEvent: twiml.RecordingEvents{twiml.RecordingCompleted}},
+		&twiml.Enqueue{WorkflowSid: "WW123", Task: &twiml.Task{Attributes: `{"a":1}`, Priority: 5}},
+		&twiml.Connect{Stream: &twiml.Stream{URL: "wss://example.com", Parameters: []twiml.Parameter{{Name: "a", Value: "b"}}}},
+		&twiml.Start{Stream: &twiml.Stream{Name: "fork", URL: "wss://example.com/fork", Track: twiml.BothTracks}},
+		&twiml.Stop{Stream: &twiml.Stream{Name: "fork"}},
+		&twiml.Pay{ValidCardTypes: twiml.CardTypes{"visa"}, Prompts: []twiml.PayPrompt{{
+			For:       twiml.PaymentCardNumber,
+			Attempt:   twiml.Attempts{1, 2},
+			ErrorType: twiml.ErrorTypes{"timeout"},
+			Prompts:   []twiml.Prompt{&twiml.Say{Text: "Card number?"}},
+		}}},
+		&twiml.Refer{Sip: &twiml.ReferSip{URI: "sip:alice@pbx.example.com"}},
+		&twiml.Message{To: "+14155550100", StatusCallback: "/status", Body: "Thanks", Media: []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}},
+		&twiml.Redirect{URL: "/next", Method: twiml.GET},
+		&twiml.Leave{},
+		&twiml.Reject{Reason: twiml.Busy},
+		&twiml.Hangup{},
+	}}
+}
+
+func TestUnmarshalRoundTrip(t *testing.T) {
+	want := marshal(t, everything())
+	var resp twiml.Response
+	if err := twiml.Unmarshal([]byte(want), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := marshal(t, &resp); got != want {
+		t.Errorf("round trip changed the document:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	const doc = `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+  <Gather action="/next" numDigits="1" hints="a, b">
+    <Say voice="alice">Press <emphasis>one</emphasis>.</Say>
+  </Gather>
+  <Dial>
+    +14155550100
+  </Dial>
+</Response>`
+	var resp twiml.Response
+	if err := twiml.Unmarshal([]byte(doc), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := []twiml.Verb{
+		&twiml.Gather{Action: "/next", NumDigits: 1, Hints: twiml.Hints{"a", "b"}, Prompts: []twiml.Prompt{
+			&twiml.Say{Voice: "alice", Text: "Press ", SSML: []twiml.SSML{
+				&twiml.Emphasis{SSML: []twiml.SSML{twiml.Text("one")}},
+				twiml.Text("."),
+			}},
+		}},
+		&twiml.Dial{Number: "+14155550100"},
+	}
+	// XMLName fields are set by decoding, so compare the re-encoded verbs.
+	if got, want := marshal(t, &resp), marshal(t, &twiml.Response{Verbs: want}); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if g := resp.Verbs[0].(*twiml.Gather); !reflect.DeepEqual(g.Hints, twiml.Hints{"a", "b"}) {
+		t.Errorf("Hints = %q", g.Hints)
+	}
+}
+
+func TestUnmarshalMessageText(t *testing.T) {
+	const doc = `<Response><Message to="+14155550100">
+  Hello
+</Message></Response>`
+	var resp twiml.Response
+	if err := twiml.Unmarshal([]byte(doc), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := &twiml.Response{Verbs: []twiml.Verb{&twiml.Message{To: "+14155550100", Body: "Hello"}}}
+	if got, want := marshal(t, &resp), marshal(t, want); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if err := twiml.Validate(&resp); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	// The re-encoded form, with a Body element, decodes the same way.
+	var again twiml.Response
+	if err := twiml.Unmarshal([]byte(marshal(t, &resp)), &again); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := marshal(t, &again), marshal(t, &resp); got != want {
+		t.Errorf("round trip changed the document:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, doc := range []string{
+		`<Reply></Reply>`,
+		`<Response><Shout/></Response>`,
+		`<Response><Gather><Dial>+14155550100</Dial></Gather></Response>`,
+		`<Response><Say loop="often">hi</Say></Response>`,
+	} {
+		var resp twiml.Response
+		err := twiml.Unmarshal([]byte(doc), &resp)
+		if err == nil || !strings.HasPrefix(err.Error(), "twiml: ") {
+			t.Errorf("Unmarshal(%s) = %v, want a twiml error", doc, err)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package twiml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate checks resp for mistakes that Twilio would only report when it
+// executes the document, mid-call: verbs that can't be used together,
+// missing required attributes and content, and values out of range. It
+// returns nil if it finds none, and otherwise an error listing them all.
+//
+// Most illegal nesting, such as a Dial inside a Gather, can't be expressed
+// with this package's types in the first place, and is rejected by
+// Unmarshal. Validate catches the rest, such as a Message in a voice
+// response or a Conference dialed alongside a Number. It can't tell
+// whether URLs are reachable or whether the document suits the webhook it
+// answers, so a Response that passes can still fail.
+//
+// Example usage:
+//
+//	if err := twiml.Validate(resp); err != nil {
+//		log.Printf("bad TwiML: %v", err)
+//	}
+func Validate(resp *Response) error {
+	c := &checker{}
+	var voice Verb
+	message := -1
+	for i, verb := range resp.Verbs {
+		switch verb.(type) {
+		case *Message:
+			if message < 0 {
+				message = i
+			}
+		case *Redirect, nil:
+		default:
+			if voice == nil {
+				voice = verb
+			}
+		}
+		c.path = fmt.Sprintf("Verbs[%d]", i)
+		c.verb(verb)
+	}
+	if voice != nil && message >= 0 {
+		c.path = fmt.Sprintf("Verbs[%d]", message)
+		c.errorf("Message can't be used with voice verbs such as %s", strings.TrimPrefix(fmt.Sprintf("%T", voice), "*twiml."))
+	}
+	return errors.Join(c.errs...)
+}
+
+// A checker collects the problems Validate finds.
+type checker struct {
+	path string
+	errs []error
+}
+
+func (c *checker) errorf(format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("twiml: %s: "+format, append([]any{c.path}, args...)...))
+}
+
+// in checks the element at path.field with f.
+func (c *checker) in(field string, f func()) {
+	saved := c.path
+	c.path += "." + field
+	f()
+	c.path = saved
+}
+
+func (c *checker) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		c.errorf("%s is required", name)
+	}
+}
+
+func (c *checker) atLeast(name string, value, min int) {
+	if value < min {
+		c.errorf("%s is %d, but can't be less than %d", name, value, min)
+	}
+}
+
+func (c *checker) method(name string, m Method) {
+	if m != "" && m != GET && m != POST {
+		c.errorf("%s is %q, but must be GET or POST", name, m)
+	}
+}
+
+func (c *checker) language(l Language) {
+	if l != "" && !l.Valid() {
+		c.errorf("Language is %q, which isn't a language code such as en-US", l)
+	}
+}
+
+func (c *checker) loop(l Loop) {
+	if l < LoopForever {
+		c.errorf("Loop is %d, but can't be negative except for LoopForever", l)
+	}
+}
+
+func (c *checker) verb(verb Verb) {
+	switch v := verb.(type) {
+	case nil:
+		c.errorf("verb is nil")
+	case *Say:
+		c.say(v)
+	case *Play:
+		c.play(v)
+	case *Pause:
+		c.atLeast("Length", v.Length, 0)
+	case *Gather:
+		c.gather(v)
+	case *Dial:
+		c.dial(v)
+	case *Record:
+		c.method("Method", v.Method)
+		c.atLeast("Timeout", v.Timeout, 0)
+		c.atLeast("MaxLength", v.MaxLength, 0)
+		c.method("RecordingStatusCallbackMethod", v.RecordingStatusCallbackMethod)
+		if v.TranscribeCallback != "" && !v.Transcribe {
+			c.errorf("TranscribeCallback is set, but Transcribe isn't")
+		}
+	case *Redirect:
+		c.required("URL", v.URL)
+		c.method("Method", v.Method)
+	case *Reject:
+		if v.Reason != "" && v.Reason != Rejected && v.Reason != Busy {
+			c.errorf("Reason is %q, but must be rejected or busy", v.Reason)
+		}
+	case *Enqueue:
+		if strings.TrimSpace(v.Name) == "" && v.WorkflowSid == "" {
+			c.errorf("Name or WorkflowSid is required")
+		}
+		if v.Task != nil && v.WorkflowSid == "" {
+			c.errorf("Task is set, but WorkflowSid isn't")
+		}
+		c.method("Method", v.Method)
+		c.method("WaitURLMethod", v.WaitURLMethod)
+	case *Message:
+		if strings.TrimSpace(v.Body) == "" && len(v.Media) == 0 {
+			c.errorf("Body or Media is required")
+		}
+		if len(v.Media) > MaxMedia {
+			c.errorf("Media has %d URLs, but can't have more than %d", len(v.Media), MaxMedia)
+		}
+		for i, m := range v.Media {
+			if strings.TrimSpace(m) == "" {
+				c.errorf("Media[%d] is empty", i)
+			}
+		}
+		c.method("Method", v.Method)
+	case *Connect:
+		c.stream(v.Stream, true)
+	case *Start:
+		c.stream(v.Stream, true)
+	case *Stop:
+		c.stream(v.Stream, false)
+	case *Pay:
+		c.pay(v)
+	case *Refer:
+		if v.Sip == nil {
+			c.errorf("Sip is required")
+		} else {
+			c.in("Sip", func() { c.required("URI", v.Sip.URI) })
+		}
+	}
+}
+
+func (c *checker) say(v *Say) {
+	if strings.TrimSpace(v.Text) == "" && len(v.SSML) == 0 {
+		c.errorf("Text or SSML is required")
+	}
+	if v.Voice != "" && !v.Voice.Valid() {
+		c.errorf("Voice is %q, which isn't a known voice", v.Voice)
+	}
+	c.language(v.Language)
+	c.loop(v.Loop)
+	c.ssml(v.SSML)
+}
+
+func (c *checker) ssml(list []SSML) {
+	for i, s := range list {
+		c.in(fmt.Sprintf("SSML[%d]", i), func() {
+			switch s := s.(type) {
+			case nil:
+				c.errorf("SSML is nil")
+			case *Emphasis:
+				c.ssml(s.SSML)
+			case *Lang:
+				c.required("Lang", s.Lang)
+				c.ssml(s.SSML)
+			case *P:
+				c.ssml(s.SSML)
+			case *S:
+				c.ssml(s.SSML)
+			case *Prosody:
+				c.ssml(s.SSML)
+			case *Phoneme:
+				c.required("Ph", s.Ph)
+			case *SayAs:
+				c.required("InterpretAs", s.InterpretAs)
+			case *Sub:
+				c.required("Alias", s.Alias)
+			}
+		})
+	}
+}
+
+func (c *checker) play(v *Play) {
+	if strings.TrimSpace(v.URL) == "" && v.Digits == "" {
+		c.errorf("URL or Digits is required")
+	}
+	if strings.Trim(v.Digits, "0123456789#*w") != "" {
+		c.errorf("Digits is %q, but may only contain 0-9, #, * and w", v.Digits)
+	}
+	c.loop(v.Loop)
+}
+
+func (c *checker) prompts(prompts []Prompt) {
+	for i, p := range prompts {
+		c.in(fmt.Sprintf("Prompts[%d]", i), func() {
+			if p == nil {
+				c.errorf("prompt is nil")
+				return
+			}
+			c.verb(p)
+		})
+	}
+}
+
+func (c *checker) gather(v *Gather) {
+	switch v.Input {
+	case "", InputDTMF, InputSpeech, InputDTMFSpeech:
+	default:
+		c.errorf("Input is %q, but must be dtmf, speech or both", v.Input)
+	}
+	c.method("Method", v.Method)
+	c.method("PartialResultCallbackMethod", v.PartialResultCallbackMethod)
+	c.atLeast("Timeout", v.Timeout, 0)
+	c.atLeast("NumDigits", v.NumDigits, 0)
+	c.language(v.Language)
+	if v.SpeechTimeout < SpeechTimeoutAuto {
+		c.errorf("SpeechTimeout is %d, but can't be negative except for SpeechTimeoutAuto", v.SpeechTimeout)
+	}
+	c.speech(v)
+	if len(v.FinishOnKey) > 1 || strings.Trim(v.FinishOnKey, "0123456789#*") != "" {
+		c.errorf("FinishOnKey is %q, but must be a single key: 0-9, # or *", v.FinishOnKey)
+	}
+	c.prompts(v.Prompts)
+}
+
+// speech checks Gather's speech recognition options.
+func (c *checker) speech(v *Gather) {
+	if v.Input != InputSpeech && v.Input != InputDTMFSpeech {
+		for _, opt := range []struct {
+			name string
+			set  bool
+		}{
+			{"SpeechTimeout", v.SpeechTimeout != 0},
+			{"Hints", len(v.Hints) > 0},
+			{"SpeechModel", v.SpeechModel != ""},
+			{"Enhanced", v.Enhanced},
+			{"ProfanityFilter", v.ProfanityFilter != nil},
+		} {
+			if opt.set {
+				c.errorf("%s is set, but Input doesn't include speech", opt.name)
+			}
+		}
+	}
+	if v.Enhanced && v.SpeechModel != SpeechModelPhoneCall {
+		c.errorf("Enhanced is set, but SpeechModel isn't SpeechModelPhoneCall")
+	}
+	if len(v.Hints) > MaxHints {
+		c.errorf("Hints has %d entries, but can't have more than %d", len(v.Hints), MaxHints)
+	}
+	for i, h := range v.Hints {
+		switch n := utf8.RuneCountInString(h); {
+		case strings.TrimSpace(h) == "":
+			c.errorf("Hints[%d] is empty", i)
+		case strings.Contains(h, ","):
+			c.errorf("Hints[%d] is %q, but can't contain a comma", i, h)
+		case n > MaxHintLength:
+			c.errorf("Hints[%d] is %d characters long, but can't be longer than %d", i, n, MaxHintLength)
+		}
+	}
+}
+
+func (c *checker) dial(v *Dial) {
+	hasNumber := strings.TrimSpace(v.Number) != ""
+	switch {
+	case !hasNumber && len(v.Nouns) == 0:
+		c.errorf("Number or Nouns is required")
+	case hasNumber && len(v.Nouns) > 0:
+		c.errorf("Number and Nouns can't both be set")
+	}
+	c.method("Method", v.Method)
+	if v.Timeout != 0 && (v.Timeout < 5 || v.Timeout > 600) {
+		c.errorf("Timeout is %d, but must be between 5 and 600 seconds", v.Timeout)
+	}
+	c.atLeast("TimeLimit", v.TimeLimit, 0)
+
+	var exclusive string // the Conference or Queue noun, which must be alone
+	for i, n := range v.Nouns {
+		c.in(fmt.Sprintf("Nouns[%d]", i), func() {
+			switch n := n.(type) {
+			case nil:
+				c.errorf("noun is nil")
+			case *Number:
+				c.required("Number", n.Number)
+				c.method("Method", n.Method)
+				c.method("StatusCallbackMethod", n.StatusCallbackMethod)
+			case *Client:
+				c.required("Identity", n.Identity)
+				c.method("Method", n.Method)
+				c.method("StatusCallbackMethod", n.StatusCallbackMethod)
+			case *Sip:
+				c.required("URI", n.URI)
+				c.method("Method", n.Method)
+				c.method("StatusCallbackMethod", n.StatusCallbackMethod)
+			case *Conference:
+				c.required("Name", n.Name)
+				c.method("WaitMethod", n.WaitMethod)
+				c.method("StatusCallbackMethod", n.StatusCallbackMethod)
+				if n.MaxParticipants != 0 && (n.MaxParticipants < 2 || n.MaxParticipants > 250) {
+					c.errorf("MaxParticipants is %d, but must be between 2 and 250", n.MaxParticipants)
+				}
+				exclusive = "Conference"
+			case *Queue:
+				if strings.TrimSpace(n.Name) == "" && n.ReservationSid == "" {
+					c.errorf("Name or ReservationSid is required")
+				}
+				c.method("Method", n.Method)
+				exclusive = "Queue"
+			}
+		})
+	}
+	if exclusive != "" && len(v.Nouns) > 1 {
+		c.errorf("a %s must be the only noun in a Dial", exclusive)
+	}
+}
+
+func (c *checker) stream(s *Stream, needURL bool) {
+	if s == nil {
+		c.errorf("Stream is required")
+		return
+	}
+	c.in("Stream", func() {
+		if needURL {
+			c.required("URL", s.URL)
+		} else {
+			c.required("Name", s.Name)
+		}
+		c.method("StatusCallbackMethod", s.StatusCallbackMethod)
+		c.parameters(s.Parameters)
+	})
+}
+
+func (c *checker) parameters(params []Parameter) {
+	for i, p := range params {
+		c.in(fmt.Sprintf("Parameters[%d]", i), func() { c.required("Name", p.Name) })
+	}
+}
+
+func (c *checker) pay(v *Pay) {
+	if v.Input != "" && v.Input != InputDTMF {
+		c.errorf("Input is %q, but Pay only supports dtmf", v.Input)
+	}
+	c.method("StatusCallbackMethod", v.StatusCallbackMethod)
+	c.language(v.Language)
+	c.atLeast("MaxAttempts", v.MaxAttempts, 0)
+	c.atLeast("Timeout", v.Timeout, 0)
+	for i, p := range v.Prompts {
+		c.in(fmt.Sprintf("Prompts[%d]", i), func() {
+			for _, n := range p.Attempt {
+				c.atLeast("Attempt", n, 1)
+			}
+			c.prompts(p.Prompts)
+		})
+	}
+	c.parameters(v.Parameters)
+}
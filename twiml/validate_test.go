@@ -0,0 +1,71 @@
+package twiml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestValidate(t *testing.T) {
+	if err := twiml.Validate(voiceResponse()); err != nil {
+		t.Errorf("valid response: got %v", err)
+	}
+
+	tests := []struct {
+		verbs []twiml.Verb
+		want  string
+	}{
+		{[]twiml.Verb{&twiml.Say{}}, "Verbs[0]: Text or SSML is required"},
+		{[]twiml.Verb{&twiml.Say{Text: "hi"}, &twiml.Message{Body: "hi"}}, "Verbs[1]: Message can't be used with voice verbs such as Say"},
+		{[]twiml.Verb{&twiml.Say{Text: "hi", Voice: "Polly.Joana"}}, `Verbs[0]: Voice is "Polly.Joana", which isn't a known voice`},
+		{[]twiml.Verb{&twiml.Say{Text: "hi", Language: "en_US"}}, `Verbs[0]: Language is "en_US", which isn't a language code`},
+		{[]twiml.Verb{&twiml.Gather{Language: "English"}}, `Verbs[0]: Language is "English"`},
+		{[]twiml.Verb{&twiml.Gather{SpeechModel: twiml.SpeechModelPhoneCall}}, "Verbs[0]: SpeechModel is set, but Input doesn't include speech"},
+		{[]twiml.Verb{&twiml.Gather{Input: twiml.InputSpeech, Enhanced: true}}, "Verbs[0]: Enhanced is set, but SpeechModel isn't SpeechModelPhoneCall"},
+		{[]twiml.Verb{&twiml.Gather{Input: twiml.InputSpeech, Hints: make(twiml.Hints, 501)}}, "Verbs[0]: Hints has 501 entries, but can't have more than 500"},
+		{[]twiml.Verb{&twiml.Gather{Input: twiml.InputSpeech, Hints: twiml.Hints{"a, b"}}}, `Verbs[0]: Hints[0] is "a, b", but can't contain a comma`},
+		{[]twiml.Verb{&twiml.Gather{Input: twiml.InputSpeech, Hints: twiml.Hints{strings.Repeat("x", 101)}}}, "Verbs[0]: Hints[0] is 101 characters long"},
+		{[]twiml.Verb{&twiml.Play{URL: "a.mp3", Loop: -2}}, "Verbs[0]: Loop is -2"},
+		{[]twiml.Verb{&twiml.Redirect{Method: "PUT", URL: "/x"}}, `Verbs[0]: Method is "PUT", but must be GET or POST`},
+		{[]twiml.Verb{&twiml.Gather{FinishOnKey: "##"}}, "Verbs[0]: FinishOnKey"},
+		{[]twiml.Verb{&twiml.Gather{Prompts: []twiml.Prompt{&twiml.Play{}}}}, "Verbs[0].Prompts[0]: URL or Digits is required"},
+		{[]twiml.Verb{&twiml.Dial{}}, "Verbs[0]: Number or Nouns is required"},
+		{[]twiml.Verb{&twiml.Dial{Number: "+14155550100", Timeout: 2}}, "Verbs[0]: Timeout is 2, but must be between 5 and 600 seconds"},
+		{[]twiml.Verb{&twiml.Dial{Nouns: []twiml.Noun{&twiml.Conference{Name: "a"}, &twiml.Number{Number: "+14155550100"}}}}, "Verbs[0]: a Conference must be the only noun in a Dial"},
+		{[]twiml.Verb{&twiml.Dial{Nouns: []twiml.Noun{&twiml.Client{}}}}, "Verbs[0].Nouns[0]: Identity is required"},
+		{[]twiml.Verb{&twiml.Say{SSML: []twiml.SSML{&twiml.Prosody{SSML: []twiml.SSML{&twiml.SayAs{Text: "1"}}}}}}, "Verbs[0].SSML[0].SSML[0]: InterpretAs is required"},
+		{[]twiml.Verb{&twiml.Connect{}}, "Verbs[0]: Stream is required"},
+		{[]twiml.Verb{&twiml.Enqueue{Name: "q", Task: &twiml.Task{}}}, "Verbs[0]: Task is set, but WorkflowSid isn't"},
+		{[]twiml.Verb{&twiml.Message{}}, "Verbs[0]: Body or Media is required"},
+		{[]twiml.Verb{&twiml.Message{Media: make([]string, 11)}}, "Verbs[0]: Media has 11 URLs, but can't have more than 10"},
+		{[]twiml.Verb{&twiml.Message{Body: "hi", Media: []string{""}}}, "Verbs[0]: Media[0] is empty"},
+		{[]twiml.Verb{&twiml.Refer{}}, "Verbs[0]: Sip is required"},
+	}
+	for _, test := range tests {
+		err := twiml.Validate(&twiml.Response{Verbs: test.verbs})
+		if err == nil || !strings.Contains(err.Error(), "twiml: "+test.want) {
+			t.Errorf("got %v, want an error containing %q", err, test.want)
+		}
+	}
+
+	// All problems are reported.
+	err := twiml.Validate(&twiml.Response{Verbs: []twiml.Verb{&twiml.Say{}, &twiml.Redirect{}}})
+	if err == nil || strings.Count(err.Error(), "twiml: ") != 2 {
+		t.Errorf("got %v, want two problems", err)
+	}
+}
+
+// voiceResponse is everything without its Message, which can't be used in a
+// voice response.
+func voiceResponse() *twiml.Response {
+	resp := everything()
+	verbs := resp.Verbs[:0]
+	for _, v := range resp.Verbs {
+		if _, ok := v.(*twiml.Message); !ok {
+			verbs = append(verbs, v)
+		}
+	}
+	resp.Verbs = verbs
+	return resp
+}
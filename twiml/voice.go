@@ -0,0 +1,312 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Say reads text aloud to the caller: Text, followed by SSML, which
+// controls how Amazon Polly and Google voices pronounce it.
+type Say struct {
+	XMLName  xml.Name `xml:"Say"`
+	Text     string   `xml:",chardata"`
+	Voice    Voice    `xml:"voice,attr,omitempty"`
+	Language Language `xml:"language,attr,omitempty"`
+	Loop     Loop     `xml:"loop,attr,omitempty"`
+	SSML     []SSML
+}
+
+// Play plays an audio file to the caller, or sends DTMF tones.
+type Play struct {
+	XMLName xml.Name `xml:"Play"`
+	URL     string   `xml:",chardata"`
+	Loop    Loop     `xml:"loop,attr,omitempty"`
+	Digits  string   `xml:"digits,attr,omitempty"`
+}
+
+// Pause waits silently for Length seconds, or one second if Length is
+// zero.
+type Pause struct {
+	XMLName xml.Name `xml:"Pause"`
+	Length  int      `xml:"length,attr,omitempty"`
+}
+
+// Gather collects digits the caller presses on their keypad, or their
+// speech, and requests Action with what it heard. Prompts are played while
+// Gather listens.
+//
+// Example usage:
+//
+//	&twiml.Gather{
+//		Input:     twiml.InputDTMFSpeech,
+//		Action:    "/menu",
+//		NumDigits: 1,
+//		Prompts: []twiml.Prompt{
+//			&twiml.Say{Text: "Press 1 or say sales."},
+//		},
+//	}
+type Gather struct {
+	XMLName                     xml.Name      `xml:"Gather"`
+	Input                       Input         `xml:"input,attr,omitempty"`
+	Action                      string        `xml:"action,attr,omitempty"`
+	Method                      Method        `xml:"method,attr,omitempty"`
+	Timeout                     int           `xml:"timeout,attr,omitempty"`
+	NumDigits                   int           `xml:"numDigits,attr,omitempty"`
+	FinishOnKey                 string        `xml:"finishOnKey,attr,omitempty"`
+	SpeechTimeout               SpeechTimeout `xml:"speechTimeout,attr,omitempty"`
+	Hints                       Hints         `xml:"hints,attr,omitempty"`
+	Language                    Language      `xml:"language,attr,omitempty"`
+	SpeechModel                 SpeechModel   `xml:"speechModel,attr,omitempty"`
+	Enhanced                    bool          `xml:"enhanced,attr,omitempty"`
+	ProfanityFilter             *bool         `xml:"profanityFilter,attr,omitempty"`
+	PartialResultCallback       string        `xml:"partialResultCallback,attr,omitempty"`
+	PartialResultCallbackMethod Method        `xml:"partialResultCallbackMethod,attr,omitempty"`
+	ActionOnEmptyResult         bool          `xml:"actionOnEmptyResult,attr,omitempty"`
+	Prompts                     []Prompt
+}
+
+// A Prompt is a verb that can be nested in Gather: *Say, *Play or *Pause.
+type Prompt interface {
+	Verb
+	prompt()
+}
+
+// An Input is the kind of input Gather listens for.
+type Input string
+
+const (
+	InputDTMF       Input = "dtmf"
+	InputSpeech     Input = "speech"
+	InputDTMFSpeech Input = "dtmf speech"
+)
+
+// SpeechTimeout is how many seconds of silence end speech input to Gather.
+// The zero value leaves the attribute out, so Gather's Timeout applies.
+type SpeechTimeout int
+
+// SpeechTimeoutAuto ends speech input when Twilio detects a pause in
+// speech.
+const SpeechTimeoutAuto SpeechTimeout = -1
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (t SpeechTimeout) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	switch {
+	case t == 0:
+		return xml.Attr{}, nil
+	case t < 0:
+		return xml.Attr{Name: name, Value: "auto"}, nil
+	}
+	return xml.Attr{Name: name, Value: strconv.Itoa(int(t))}, nil
+}
+
+// A SpeechModel is the speech recognition model Gather uses. Enhanced
+// recognition is only available with SpeechModelPhoneCall.
+type SpeechModel string
+
+const (
+	SpeechModelDefault                   SpeechModel = "default"
+	SpeechModelNumbersAndCommands        SpeechModel = "numbers_and_commands"
+	SpeechModelPhoneCall                 SpeechModel = "phone_call"
+	SpeechModelExperimentalConversations SpeechModel = "experimental_conversations"
+	SpeechModelExperimentalUtterances    SpeechModel = "experimental_utterances"
+	SpeechModelGoogleV2Long              SpeechModel = "googlev2_long"
+	SpeechModelGoogleV2Short             SpeechModel = "googlev2_short"
+	SpeechModelGoogleV2Telephony         SpeechModel = "googlev2_telephony"
+	SpeechModelDeepgramNova2             SpeechModel = "deepgram_nova-2"
+	SpeechModelDeepgramNova3             SpeechModel = "deepgram_nova-3"
+)
+
+// Hints are words or phrases Gather is likely to hear, to improve speech
+// recognition. Besides literal phrases, hints can be classes such as
+// HintDigitSequence, which make the recognizer expect a kind of value. A
+// Gather can have at most MaxHints hints of at most MaxHintLength
+// characters each.
+type Hints []string
+
+// Limits on Hints, which Validate checks.
+const (
+	MaxHints      = 500
+	MaxHintLength = 100
+)
+
+// Classes of value that can be given as Hints.
+const (
+	HintDigitSequence        = "$OOV_CLASS_DIGIT_SEQUENCE"
+	HintAlphaSequence        = "$OOV_CLASS_ALPHA_SEQUENCE"
+	HintAlphanumericSequence = "$OOV_CLASS_ALPHANUMERIC_SEQUENCE"
+	HintFullPhoneNumber      = "$FULLPHONENUM"
+	HintAddressNumber        = "$ADDRESSNUM"
+	HintPostalCode           = "$POSTALCODE"
+	HintMoney                = "$MONEY"
+	HintDay                  = "$DAY"
+	HintMonth                = "$MONTH"
+	HintYear                 = "$YEAR"
+	HintOrdinal              = "$ORDINAL"
+	HintOperand              = "$OPERAND"
+	HintTime                 = "$TIME"
+)
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (h Hints) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if len(h) == 0 {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: strings.Join(h, ",")}, nil
+}
+
+// Dial connects the caller to another party: the phone number in Number,
+// or the parties described by Nouns.
+type Dial struct {
+	XMLName      xml.Name `xml:"Dial"`
+	Number       string   `xml:",chardata"`
+	Action       string   `xml:"action,attr,omitempty"`
+	Method       Method   `xml:"method,attr,omitempty"`
+	Timeout      int      `xml:"timeout,attr,omitempty"`
+	TimeLimit    int      `xml:"timeLimit,attr,omitempty"`
+	CallerID     string   `xml:"callerId,attr,omitempty"`
+	HangupOnStar bool     `xml:"hangupOnStar,attr,omitempty"`
+	Nouns        []Noun
+}
+
+// Record records the caller's voice. When recording ends, Twilio requests
+// Action with the RecordingUrl, RecordingDuration and Digits parameters;
+// without an Action, it requests the current document again. The recording
+// is also reported to RecordingStatusCallback as it progresses, and if
+// Transcribe is set its transcription is sent to TranscribeCallback.
+//
+// Example usage:
+//
+//	&twiml.Record{
+//		Action:             "/voicemail/done",
+//		MaxLength:          120,
+//		PlayBeep:           twiml.Bool(true),
+//		Trim:               twiml.TrimSilence,
+//		Transcribe:         true,
+//		TranscribeCallback: "/voicemail/transcribed",
+//	}
+type Record struct {
+	XMLName                       xml.Name        `xml:"Record"`
+	Action                        string          `xml:"action,attr,omitempty"`
+	Method                        Method          `xml:"method,attr,omitempty"`
+	Timeout                       int             `xml:"timeout,attr,omitempty"`
+	MaxLength                     int             `xml:"maxLength,attr,omitempty"`
+	FinishOnKey                   string          `xml:"finishOnKey,attr,omitempty"`
+	PlayBeep                      *bool           `xml:"playBeep,attr,omitempty"`
+	Trim                          Trim            `xml:"trim,attr,omitempty"`
+	RecordingStatusCallback       string          `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod Method          `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallbackEvent  RecordingEvents `xml:"recordingStatusCallbackEvent,attr,omitempty"`
+	Transcribe                    bool            `xml:"transcribe,attr,omitempty"`
+	TranscribeCallback            string          `xml:"transcribeCallback,attr,omitempty"`
+}
+
+// Trim is whether silence is trimmed from the start and end of a
+// recording.
+type Trim string
+
+const (
+	TrimSilence Trim = "trim-silence"
+	DoNotTrim   Trim = "do-not-trim"
+)
+
+// A RecordingEvent is a change in the state of a recording that Twilio can
+// report to a recording status callback.
+type RecordingEvent string
+
+const (
+	RecordingInProgress RecordingEvent = "in-progress"
+	RecordingCompleted  RecordingEvent = "completed"
+	RecordingAbsent     RecordingEvent = "absent"
+)
+
+// RecordingEvents are the events reported to a recording status callback.
+// Without them, only RecordingCompleted is reported.
+type RecordingEvents []RecordingEvent
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (e RecordingEvents) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return spaceList(name, e), nil
+}
+
+// Redirect hands control of the call to the TwiML at URL.
+type Redirect struct {
+	XMLName xml.Name `xml:"Redirect"`
+	URL     string   `xml:",chardata"`
+	Method  Method   `xml:"method,attr,omitempty"`
+}
+
+// Hangup ends the call.
+type Hangup struct {
+	XMLName xml.Name `xml:"Hangup"`
+}
+
+// A RejectReason is the signal Reject plays to the caller.
+type RejectReason string
+
+const (
+	Rejected RejectReason = "rejected"
+	Busy     RejectReason = "busy"
+)
+
+// Reject declines an incoming call without answering it, so it isn't
+// billed.
+type Reject struct {
+	XMLName xml.Name     `xml:"Reject"`
+	Reason  RejectReason `xml:"reason,attr,omitempty"`
+}
+
+// Enqueue puts the caller in the call queue named Name, where they hear
+// the TwiML at WaitURL until they are dequeued or leave. With TaskRouter,
+// set WorkflowSid and Task instead of Name to create a task for the call.
+//
+// Example usage:
+//
+//	&twiml.Enqueue{
+//		WorkflowSid: workflowSid,
+//		WaitURL:     "/hold-music",
+//		Task:        &twiml.Task{Attributes: `{"language":"es"}`, Priority: 5},
+//	}
+type Enqueue struct {
+	XMLName       xml.Name `xml:"Enqueue"`
+	Name          string   `xml:",chardata"`
+	Action        string   `xml:"action,attr,omitempty"`
+	Method        Method   `xml:"method,attr,omitempty"`
+	WaitURL       string   `xml:"waitUrl,attr,omitempty"`
+	WaitURLMethod Method   `xml:"waitUrlMethod,attr,omitempty"`
+	MaxQueueSize  int      `xml:"maxQueueSize,attr,omitempty"`
+	WorkflowSid   string   `xml:"workflowSid,attr,omitempty"`
+	Task          *Task
+}
+
+// Task describes the TaskRouter task Enqueue creates. Attributes is a JSON
+// object.
+type Task struct {
+	XMLName    xml.Name `xml:"Task"`
+	Attributes string   `xml:",chardata"`
+	Priority   int      `xml:"priority,attr,omitempty"`
+	Timeout    int      `xml:"timeout,attr,omitempty"`
+}
+
+// Leave takes the caller out of the queue they are waiting in, and
+// continues the call with the verbs after the Enqueue that put them there.
+// It can only be used in the TwiML of an Enqueue's WaitURL.
+type Leave struct {
+	XMLName xml.Name `xml:"Leave"`
+}
+
+func (*Say) verb()      {}
+func (*Play) verb()     {}
+func (*Pause) verb()    {}
+func (*Gather) verb()   {}
+func (*Dial) verb()     {}
+func (*Record) verb()   {}
+func (*Redirect) verb() {}
+func (*Hangup) verb()   {}
+func (*Reject) verb()   {}
+func (*Enqueue) verb()  {}
+func (*Leave) verb()    {}
+
+func (*Say) prompt()   {}
+func (*Play) prompt()  {}
+func (*Pause) prompt() {}
@@ -0,0 +1,86 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestVoiceVerbs(t *testing.T) {
+	tests := []struct {
+		verb twiml.Verb
+		want string
+	}{
+		{&twiml.Say{Text: "Hello", Voice: "alice", Language: "en-GB"}, `<Say voice="alice" language="en-GB">Hello</Say>`},
+		{&twiml.Play{Digits: "wwww3"}, `<Play digits="wwww3"></Play>`},
+		{&twiml.Pause{Length: 2}, `<Pause length="2"></Pause>`},
+		{&twiml.Gather{Action: "/menu", Method: twiml.GET, NumDigits: 1}, `<Gather action="/menu" method="GET" numDigits="1"></Gather>`},
+		{&twiml.Dial{Number: "+14155550100", CallerID: "+14155550199", HangupOnStar: true}, `<Dial callerId="+14155550199" hangupOnStar="true">+14155550100</Dial>`},
+		{&twiml.Record{Action: "/recorded", MaxLength: 30, FinishOnKey: "#"}, `<Record action="/recorded" maxLength="30" finishOnKey="#"></Record>`},
+		{&twiml.Redirect{URL: "/next", Method: twiml.POST}, `<Redirect method="POST">/next</Redirect>`},
+		{&twiml.Hangup{}, `<Hangup></Hangup>`},
+		{&twiml.Reject{Reason: twiml.Busy}, `<Reject reason="busy"></Reject>`},
+		{&twiml.Enqueue{Name: "support", WaitURL: "/hold"}, `<Enqueue waitUrl="/hold">support</Enqueue>`},
+		{
+			&twiml.Enqueue{WorkflowSid: "WW123", Task: &twiml.Task{Attributes: `{"language":"es"}`, Priority: 5}},
+			`<Enqueue workflowSid="WW123"><Task priority="5">{&#34;language&#34;:&#34;es&#34;}</Task></Enqueue>`,
+		},
+		{&twiml.Leave{}, `<Leave></Leave>`},
+	}
+	for _, test := range tests {
+		got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{test.verb}})
+		if want := "<Response>" + test.want + "</Response>"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestGather(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Gather{
+		Input:                 twiml.InputDTMFSpeech,
+		Action:                "/menu",
+		Timeout:               3,
+		SpeechTimeout:         twiml.SpeechTimeoutAuto,
+		Hints:                 twiml.Hints{"sales", "support", twiml.HintDigitSequence},
+		Language:              "en-US",
+		SpeechModel:           twiml.SpeechModelPhoneCall,
+		Enhanced:              true,
+		ProfanityFilter:       twiml.Bool(false),
+		PartialResultCallback: "/partial",
+		Prompts: []twiml.Prompt{
+			&twiml.Say{Text: "Press 1 or say sales."},
+			&twiml.Pause{},
+			&twiml.Play{URL: "menu.mp3"},
+		},
+	}}})
+	want := `<Response><Gather input="dtmf speech" action="/menu" timeout="3" speechTimeout="auto" hints="sales,support,$OOV_CLASS_DIGIT_SEQUENCE" language="en-US" ` +
+		`speechModel="phone_call" enhanced="true" profanityFilter="false" partialResultCallback="/partial">` +
+		`<Say>Press 1 or say sales.</Say><Pause></Pause><Play>menu.mp3</Play>` +
+		`</Gather></Response>`
+	if got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+
+	got = marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Gather{SpeechTimeout: 2}}})
+	if want := `<Response><Gather speechTimeout="2"></Gather></Response>`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	got := marshal(t, &twiml.Response{Verbs: []twiml.Verb{&twiml.Record{
+		Action:                       "/done",
+		MaxLength:                    120,
+		PlayBeep:                     twiml.Bool(false),
+		Trim:                         twiml.DoNotTrim,
+		RecordingStatusCallback:      "/status",
+		RecordingStatusCallbackEvent: twiml.RecordingEvents{twiml.RecordingInProgress, twiml.RecordingCompleted},
+		Transcribe:                   true,
+		TranscribeCallback:           "/transcribed",
+	}}})
+	want := `<Response><Record action="/done" maxLength="120" playBeep="false" trim="do-not-trim" recordingStatusCallback="/status" ` +
+		`recordingStatusCallbackEvent="in-progress completed" transcribe="true" transcribeCallback="/transcribed"></Record></Response>`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
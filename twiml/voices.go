@@ -0,0 +1,349 @@
+package twiml
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Voice names a text-to-speech voice for Say. Besides the constants below,
+// Twilio offers Amazon Polly voices in their neural and generative forms,
+// such as PollyJoanna.Neural(), and Google voices, named with GoogleVoice.
+//
+// Twilio falls back to its default voice when it doesn't recognize one, so
+// a misspelled Voice goes unnoticed until someone listens to the call.
+// Validate reports voices that aren't known to this package.
+type Voice string
+
+// The basic voices.
+const (
+	VoiceMan   Voice = "man"
+	VoiceWoman Voice = "woman"
+	VoiceAlice Voice = "alice"
+)
+
+// The Amazon Polly voices, in their standard form.
+const (
+	PollyAditi     Voice = "Polly.Aditi"
+	PollyAdriano   Voice = "Polly.Adriano"
+	PollyAmy       Voice = "Polly.Amy"
+	PollyAria      Voice = "Polly.Aria"
+	PollyArlet     Voice = "Polly.Arlet"
+	PollyArthur    Voice = "Polly.Arthur"
+	PollyAstrid    Voice = "Polly.Astrid"
+	PollyAyanda    Voice = "Polly.Ayanda"
+	PollyBianca    Voice = "Polly.Bianca"
+	PollyBrian     Voice = "Polly.Brian"
+	PollyBurak     Voice = "Polly.Burak"
+	PollyCamila    Voice = "Polly.Camila"
+	PollyCarla     Voice = "Polly.Carla"
+	PollyCarmen    Voice = "Polly.Carmen"
+	PollyCeline    Voice = "Polly.Celine"
+	PollyChantal   Voice = "Polly.Chantal"
+	PollyConchita  Voice = "Polly.Conchita"
+	PollyCristiano Voice = "Polly.Cristiano"
+	PollyDaniel    Voice = "Polly.Daniel"
+	PollyDanielle  Voice = "Polly.Danielle"
+	PollyDora      Voice = "Polly.Dora"
+	PollyElin      Voice = "Polly.Elin"
+	PollyEmma      Voice = "Polly.Emma"
+	PollyEnrique   Voice = "Polly.Enrique"
+	PollyEwa       Voice = "Polly.Ewa"
+	PollyFiliz     Voice = "Polly.Filiz"
+	PollyGabrielle Voice = "Polly.Gabrielle"
+	PollyGeraint   Voice = "Polly.Geraint"
+	PollyGiorgio   Voice = "Polly.Giorgio"
+	PollyGregory   Voice = "Polly.Gregory"
+	PollyGwyneth   Voice = "Polly.Gwyneth"
+	PollyHala      Voice = "Polly.Hala"
+	PollyHannah    Voice = "Polly.Hannah"
+	PollyHans      Voice = "Polly.Hans"
+	PollyHiujin    Voice = "Polly.Hiujin"
+	PollyIda       Voice = "Polly.Ida"
+	PollyInes      Voice = "Polly.Ines"
+	PollyIsabelle  Voice = "Polly.Isabelle"
+	PollyIvy       Voice = "Polly.Ivy"
+	PollyJacek     Voice = "Polly.Jacek"
+	PollyJan       Voice = "Polly.Jan"
+	PollyJihye     Voice = "Polly.Jihye"
+	PollyJitka     Voice = "Polly.Jitka"
+	PollyJoanna    Voice = "Polly.Joanna"
+	PollyJoey      Voice = "Polly.Joey"
+	PollyJustin    Voice = "Polly.Justin"
+	PollyKajal     Voice = "Polly.Kajal"
+	PollyKarl      Voice = "Polly.Karl"
+	PollyKazuha    Voice = "Polly.Kazuha"
+	PollyKendra    Voice = "Polly.Kendra"
+	PollyKevin     Voice = "Polly.Kevin"
+	PollyKimberly  Voice = "Polly.Kimberly"
+	PollyLaura     Voice = "Polly.Laura"
+	PollyLea       Voice = "Polly.Lea"
+	PollyLiam      Voice = "Polly.Liam"
+	PollyLisa      Voice = "Polly.Lisa"
+	PollyLiv       Voice = "Polly.Liv"
+	PollyLotte     Voice = "Polly.Lotte"
+	PollyLucia     Voice = "Polly.Lucia"
+	PollyLupe      Voice = "Polly.Lupe"
+	PollyMads      Voice = "Polly.Mads"
+	PollyMaja      Voice = "Polly.Maja"
+	PollyMarlene   Voice = "Polly.Marlene"
+	PollyMathieu   Voice = "Polly.Mathieu"
+	PollyMatthew   Voice = "Polly.Matthew"
+	PollyMaxim     Voice = "Polly.Maxim"
+	PollyMia       Voice = "Polly.Mia"
+	PollyMiguel    Voice = "Polly.Miguel"
+	PollyMizuki    Voice = "Polly.Mizuki"
+	PollyNaja      Voice = "Polly.Naja"
+	PollyNiamh     Voice = "Polly.Niamh"
+	PollyNicole    Voice = "Polly.Nicole"
+	PollyOla       Voice = "Polly.Ola"
+	PollyOlivia    Voice = "Polly.Olivia"
+	PollyPedro     Voice = "Polly.Pedro"
+	PollyPenelope  Voice = "Polly.Penelope"
+	PollyRaveena   Voice = "Polly.Raveena"
+	PollyRemi      Voice = "Polly.Remi"
+	PollyRicardo   Voice = "Polly.Ricardo"
+	PollyRuben     Voice = "Polly.Ruben"
+	PollyRussell   Voice = "Polly.Russell"
+	PollyRuth      Voice = "Polly.Ruth"
+	PollySabrina   Voice = "Polly.Sabrina"
+	PollySalli     Voice = "Polly.Salli"
+	PollySeoyeon   Voice = "Polly.Seoyeon"
+	PollySergio    Voice = "Polly.Sergio"
+	PollySofie     Voice = "Polly.Sofie"
+	PollyStephen   Voice = "Polly.Stephen"
+	PollySuvi      Voice = "Polly.Suvi"
+	PollyTakumi    Voice = "Polly.Takumi"
+	PollyTatyana   Voice = "Polly.Tatyana"
+	PollyThiago    Voice = "Polly.Thiago"
+	PollyTomoko    Voice = "Polly.Tomoko"
+	PollyVicki     Voice = "Polly.Vicki"
+	PollyVitoria   Voice = "Polly.Vitoria"
+	PollyZayd      Voice = "Polly.Zayd"
+	PollyZeina     Voice = "Polly.Zeina"
+	PollyZhiyu     Voice = "Polly.Zhiyu"
+)
+
+// pollyLanguages maps each Polly voice to the language it speaks.
+var pollyLanguages = map[Voice]Language{
+	PollyAditi:     LanguageHiIN,
+	PollyAdriano:   LanguageItIT,
+	PollyAmy:       LanguageEnGB,
+	PollyAria:      LanguageEnNZ,
+	PollyArlet:     LanguageCaES,
+	PollyArthur:    LanguageEnGB,
+	PollyAstrid:    LanguageSvSE,
+	PollyAyanda:    LanguageEnZA,
+	PollyBianca:    LanguageItIT,
+	PollyBrian:     LanguageEnGB,
+	PollyBurak:     LanguageTrTR,
+	PollyCamila:    LanguagePtBR,
+	PollyCarla:     LanguageItIT,
+	PollyCarmen:    LanguageRoRO,
+	PollyCeline:    LanguageFrFR,
+	PollyChantal:   LanguageFrCA,
+	PollyConchita:  LanguageEsES,
+	PollyCristiano: LanguagePtPT,
+	PollyDaniel:    LanguageDeDE,
+	PollyDanielle:  LanguageEnUS,
+	PollyDora:      LanguageIsIS,
+	PollyElin:      LanguageSvSE,
+	PollyEmma:      LanguageEnGB,
+	PollyEnrique:   LanguageEsES,
+	PollyEwa:       LanguagePlPL,
+	PollyFiliz:     LanguageTrTR,
+	PollyGabrielle: LanguageFrCA,
+	PollyGeraint:   LanguageEnGBWLS,
+	PollyGiorgio:   LanguageItIT,
+	PollyGregory:   LanguageEnUS,
+	PollyGwyneth:   LanguageCyGB,
+	PollyHala:      LanguageArAE,
+	PollyHannah:    LanguageDeAT,
+	PollyHans:      LanguageDeDE,
+	PollyHiujin:    LanguageYueCN,
+	PollyIda:       LanguageNbNO,
+	PollyInes:      LanguagePtPT,
+	PollyIsabelle:  LanguageFrBE,
+	PollyIvy:       LanguageEnUS,
+	PollyJacek:     LanguagePlPL,
+	PollyJan:       LanguagePlPL,
+	PollyJihye:     LanguageKoKR,
+	PollyJitka:     LanguageCsCZ,
+	PollyJoanna:    LanguageEnUS,
+	PollyJoey:      LanguageEnUS,
+	PollyJustin:    LanguageEnUS,
+	PollyKajal:     LanguageEnIN,
+	PollyKarl:      LanguageIsIS,
+	PollyKazuha:    LanguageJaJP,
+	PollyKendra:    LanguageEnUS,
+	PollyKevin:     LanguageEnUS,
+	PollyKimberly:  LanguageEnUS,
+	PollyLaura:     LanguageNlNL,
+	PollyLea:       LanguageFrFR,
+	PollyLiam:      LanguageFrCA,
+	PollyLisa:      LanguageNlBE,
+	PollyLiv:       LanguageNbNO,
+	PollyLotte:     LanguageNlNL,
+	PollyLucia:     LanguageEsES,
+	PollyLupe:      LanguageEsUS,
+	PollyMads:      LanguageDaDK,
+	PollyMaja:      LanguagePlPL,
+	PollyMarlene:   LanguageDeDE,
+	PollyMathieu:   LanguageFrFR,
+	PollyMatthew:   LanguageEnUS,
+	PollyMaxim:     LanguageRuRU,
+	PollyMia:       LanguageEsMX,
+	PollyMiguel:    LanguageEsUS,
+	PollyMizuki:    LanguageJaJP,
+	PollyNaja:      LanguageDaDK,
+	PollyNiamh:     LanguageEnIE,
+	PollyNicole:    LanguageEnAU,
+	PollyOla:       LanguagePlPL,
+	PollyOlivia:    LanguageEnAU,
+	PollyPedro:     LanguageEsUS,
+	PollyPenelope:  LanguageEsUS,
+	PollyRaveena:   LanguageEnIN,
+	PollyRemi:      LanguageFrFR,
+	PollyRicardo:   LanguagePtBR,
+	PollyRuben:     LanguageNlNL,
+	PollyRussell:   LanguageEnAU,
+	PollyRuth:      LanguageEnUS,
+	PollySabrina:   LanguageDeCH,
+	PollySalli:     LanguageEnUS,
+	PollySeoyeon:   LanguageKoKR,
+	PollySergio:    LanguageEsES,
+	PollySofie:     LanguageDaDK,
+	PollyStephen:   LanguageEnUS,
+	PollySuvi:      LanguageFiFI,
+	PollyTakumi:    LanguageJaJP,
+	PollyTatyana:   LanguageRuRU,
+	PollyThiago:    LanguagePtBR,
+	PollyTomoko:    LanguageJaJP,
+	PollyVicki:     LanguageDeDE,
+	PollyVitoria:   LanguagePtBR,
+	PollyZayd:      LanguageArAE,
+	PollyZeina:     LanguageArb,
+	PollyZhiyu:     LanguageCmnCN,
+}
+
+// Neural returns the neural form of a standard Polly voice, such as
+// Polly.Joanna-Neural for PollyJoanna. Not every voice has one.
+func (v Voice) Neural() Voice { return v.pollyEngine("-Neural") }
+
+// Generative returns the generative form of a standard Polly voice, such
+// as Polly.Ruth-Generative for PollyRuth. Not every voice has one.
+func (v Voice) Generative() Voice { return v.pollyEngine("-Generative") }
+
+func (v Voice) pollyEngine(suffix string) Voice {
+	return Voice(pollyName(v)) + Voice(suffix)
+}
+
+// pollyName returns a Polly voice without its engine suffix.
+func pollyName(v Voice) Voice {
+	for _, suffix := range []string{"-Neural", "-Generative", "-LongForm"} {
+		if s, ok := strings.CutSuffix(string(v), suffix); ok {
+			return Voice(s)
+		}
+	}
+	return v
+}
+
+// GoogleVoice returns the Google voice with the given name in lang, such
+// as GoogleVoice(LanguageEnUS, "Neural2-F") for Google.en-US-Neural2-F.
+func GoogleVoice(lang Language, name string) Voice {
+	return Voice("Google." + string(lang) + "-" + name)
+}
+
+// googleModels are the kinds of Google voice Twilio offers.
+var googleModels = []string{"Standard", "Wavenet", "Neural2", "News", "Studio", "Polyglot", "Journey", "Chirp-HD", "Chirp3-HD"}
+
+// Language returns the language v speaks, or the empty string for the
+// basic voices, which speak whatever Say's Language is.
+func (v Voice) Language() Language {
+	if lang, ok := pollyLanguages[pollyName(v)]; ok {
+		return lang
+	}
+	if rest, ok := strings.CutPrefix(string(v), "Google."); ok {
+		for _, model := range googleModels {
+			if lang, name, ok := strings.Cut(rest, "-"+model+"-"); ok && name != "" && Language(lang).Valid() {
+				return Language(lang)
+			}
+		}
+	}
+	return ""
+}
+
+// Valid reports whether v is a voice known to this package: a basic voice,
+// a Polly voice in any of its forms, or a Google voice whose name is well
+// formed.
+func (v Voice) Valid() bool {
+	switch v {
+	case VoiceMan, VoiceWoman, VoiceAlice:
+		return true
+	}
+	return v.Language() != ""
+}
+
+// Language is a language code, such as en-US, for Say, Gather and Pay.
+// Say's Language chooses how the basic voices speak; Gather's and Pay's
+// choose the language of speech recognition, for which Twilio supports many
+// more languages than have constants here.
+type Language string
+
+// Languages of the Say voices.
+const (
+	LanguageArAE    Language = "ar-AE"     // Arabic (Gulf)
+	LanguageArb     Language = "arb"       // Arabic
+	LanguageCaES    Language = "ca-ES"     // Catalan
+	LanguageCmnCN   Language = "cmn-CN"    // Mandarin Chinese
+	LanguageCsCZ    Language = "cs-CZ"     // Czech
+	LanguageCyGB    Language = "cy-GB"     // Welsh
+	LanguageDaDK    Language = "da-DK"     // Danish
+	LanguageDeAT    Language = "de-AT"     // German (Austria)
+	LanguageDeCH    Language = "de-CH"     // German (Switzerland)
+	LanguageDeDE    Language = "de-DE"     // German
+	LanguageEnAU    Language = "en-AU"     // English (Australia)
+	LanguageEnCA    Language = "en-CA"     // English (Canada)
+	LanguageEnGB    Language = "en-GB"     // English (UK)
+	LanguageEnGBWLS Language = "en-GB-WLS" // English (Wales)
+	LanguageEnIE    Language = "en-IE"     // English (Ireland)
+	LanguageEnIN    Language = "en-IN"     // English (India)
+	LanguageEnNZ    Language = "en-NZ"     // English (New Zealand)
+	LanguageEnUS    Language = "en-US"     // English (US)
+	LanguageEnZA    Language = "en-ZA"     // English (South Africa)
+	LanguageEsES    Language = "es-ES"     // Spanish (Spain)
+	LanguageEsMX    Language = "es-MX"     // Spanish (Mexico)
+	LanguageEsUS    Language = "es-US"     // Spanish (US)
+	LanguageFiFI    Language = "fi-FI"     // Finnish
+	LanguageFrBE    Language = "fr-BE"     // French (Belgium)
+	LanguageFrCA    Language = "fr-CA"     // French (Canada)
+	LanguageFrFR    Language = "fr-FR"     // French (France)
+	LanguageHiIN    Language = "hi-IN"     // Hindi
+	LanguageIsIS    Language = "is-IS"     // Icelandic
+	LanguageItIT    Language = "it-IT"     // Italian
+	LanguageJaJP    Language = "ja-JP"     // Japanese
+	LanguageKoKR    Language = "ko-KR"     // Korean
+	LanguageNbNO    Language = "nb-NO"     // Norwegian
+	LanguageNlBE    Language = "nl-BE"     // Dutch (Belgium)
+	LanguageNlNL    Language = "nl-NL"     // Dutch
+	LanguagePlPL    Language = "pl-PL"     // Polish
+	LanguagePtBR    Language = "pt-BR"     // Portuguese (Brazil)
+	LanguagePtPT    Language = "pt-PT"     // Portuguese (Portugal)
+	LanguageRoRO    Language = "ro-RO"     // Romanian
+	LanguageRuRU    Language = "ru-RU"     // Russian
+	LanguageSvSE    Language = "sv-SE"     // Swedish
+	LanguageTrTR    Language = "tr-TR"     // Turkish
+	LanguageYueCN   Language = "yue-CN"    // Cantonese
+	LanguageZhCN    Language = "zh-CN"     // Chinese (Mandarin)
+	LanguageZhHK    Language = "zh-HK"     // Chinese (Cantonese)
+	LanguageZhTW    Language = "zh-TW"     // Chinese (Taiwanese Mandarin)
+)
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?(-[A-Z]{3})?$`)
+
+// Valid reports whether l is a well-formed language code: a two- or
+// three-letter language, optionally followed by a script and a region,
+// as in en, en-US, cmn-Hans-CN or en-GB-WLS. It catches mistakes such as
+// en_US or English, but not codes for languages Twilio doesn't support.
+func (l Language) Valid() bool {
+	return languagePattern.MatchString(string(l))
+}
@@ -0,0 +1,52 @@
+package twiml_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+func TestVoice(t *testing.T) {
+	tests := []struct {
+		voice twiml.Voice
+		lang  twiml.Language
+		valid bool
+	}{
+		{twiml.VoiceAlice, "", true},
+		{twiml.PollyJoanna, twiml.LanguageEnUS, true},
+		{twiml.PollyJoanna.Neural(), twiml.LanguageEnUS, true},
+		{twiml.PollyRuth.Generative(), twiml.LanguageEnUS, true},
+		{twiml.PollyGeraint, twiml.LanguageEnGBWLS, true},
+		{twiml.GoogleVoice(twiml.LanguageEnGB, "Neural2-A"), twiml.LanguageEnGB, true},
+		{"Google.cmn-CN-Chirp3-HD-Aoede", twiml.LanguageCmnCN, true},
+		{"Polly.Joana", "", false},
+		{"Polly.joanna", "", false},
+		{"Google.en-US-Neural2-", "", false},
+		{"Google.english-Standard-A", "", false},
+		{"Joanna", "", false},
+	}
+	for _, test := range tests {
+		if got := test.voice.Language(); got != test.lang {
+			t.Errorf("%s.Language() = %q, want %q", test.voice, got, test.lang)
+		}
+		if got := test.voice.Valid(); got != test.valid {
+			t.Errorf("%s.Valid() = %v, want %v", test.voice, got, test.valid)
+		}
+	}
+	if got := twiml.PollyJoanna.Neural().Generative(); got != "Polly.Joanna-Generative" {
+		t.Errorf("Neural().Generative() = %q", got)
+	}
+}
+
+func TestLanguageValid(t *testing.T) {
+	for _, l := range []twiml.Language{"en", "en-US", "arb", "cmn-Hans-CN", "es-419", twiml.LanguageEnGBWLS} {
+		if !l.Valid() {
+			t.Errorf("%q should be valid", l)
+		}
+	}
+	for _, l := range []twiml.Language{"", "en_US", "English", "en-us", "EN-US"} {
+		if l.Valid() {
+			t.Errorf("%q shouldn't be valid", l)
+		}
+	}
+}
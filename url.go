@@ -0,0 +1,208 @@
+package twilio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A URLFunc returns the URL, including the full query string, that Twilio
+// signed when it sent r.
+type URLFunc func(r *http.Request) string
+
+// RequestURL is the URLFunc used by IsValid and ValidateRequest. If r.URL is
+// absolute, as it is for client requests, it is used as is. Otherwise, as is
+// the case for requests received by a server, the URL is rebuilt from r.Host
+// and whether the request arrived over TLS.
+func RequestURL(r *http.Request) string {
+	if r.URL.IsAbs() {
+		return r.URL.String()
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// ForwardedURL is a URLFunc for servers that sit behind a load balancer or
+// TLS-terminating proxy. It rebuilds the public URL of r from the Forwarded
+// header (RFC 7239), or failing that from the X-Forwarded-Proto and
+// X-Forwarded-Host headers. Anything the headers don't say is taken from r
+// as in RequestURL.
+//
+// Only use ForwardedURL when every request passes through a proxy that sets
+// these headers. Otherwise a client can choose the URL being validated.
+//
+// Example usage:
+//
+//	err := twilio.ValidateRequestURL(key, r, twilio.ForwardedURL(r))
+func ForwardedURL(r *http.Request) string {
+	scheme, host := forwarded(r)
+	if scheme == "" {
+		scheme = firstValue(r.Header.Get("X-Forwarded-Proto"))
+	}
+	if host == "" {
+		host = firstValue(r.Header.Get("X-Forwarded-Host"))
+	}
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+	if host == "" {
+		host = r.Host
+	}
+	return strings.ToLower(scheme) + "://" + host + r.URL.RequestURI()
+}
+
+// ForwardedProtoURL is a URLFunc for servers behind a TLS-terminating proxy
+// that passes the Host header through unchanged, as most platform routers
+// do. It is like ForwardedURL, but takes only the scheme from the
+// X-Forwarded-Proto header and always uses r.Host, so clients can't choose
+// the host being validated. The scheme is the last entry of the header,
+// the one the proxy added; see BehindProxy for more than one proxy.
+func ForwardedProtoURL(r *http.Request) string {
+	scheme := forwardedProto(r, 1)
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// BaseURL returns a URLFunc that ignores the scheme and host the request
+// arrived with and uses those of base instead. If base has a path, it is
+// prepended to the request path, so a handler mounted at /sms behind a
+// rewrite of https://example.com/twilio/sms can be validated with
+// BaseURL("https://example.com/twilio").
+//
+// BaseURL panics if base is not an absolute URL. It is intended to be called
+// once, with a value from configuration, when setting up handlers.
+//
+// Example usage:
+//
+//	publicURL := twilio.BaseURL("https://hooks.example.com")
+//	err := twilio.ValidateRequestURL(key, r, publicURL(r))
+func BaseURL(base string) URLFunc {
+	u, err := url.Parse(base)
+	if err != nil {
+		panic("twilio: BaseURL: " + err.Error())
+	}
+	if !u.IsAbs() || u.Host == "" {
+		panic("twilio: BaseURL: " + base + " is not an absolute URL")
+	}
+	prefix := u.Scheme + "://" + u.Host + strings.TrimSuffix(u.EscapedPath(), "/")
+	return func(r *http.Request) string {
+		return prefix + r.URL.RequestURI()
+	}
+}
+
+// rawURL replaces the path and query of signedURL, which a URLFunc built
+// from r.URL, with r.RequestURI, the request target exactly as the client
+// sent it. URLs that don't end in the re-encoded request target are
+// returned unchanged.
+func rawURL(r *http.Request, signedURL string) string {
+	if !strings.HasPrefix(r.RequestURI, "/") {
+		return signedURL
+	}
+	prefix, ok := strings.CutSuffix(signedURL, r.URL.RequestURI())
+	if !ok {
+		return signedURL
+	}
+	return prefix + r.RequestURI
+}
+
+// portVariant appends to dst the other form of u that Twilio may have
+// signed. Per Twilio's canonicalization rules, a default port (:443 for
+// https, :80 for http) may or may not be part of the signed URL, so a URL
+// with no port gets a variant with the default port, and a URL with the
+// default port gets one without. A non-standard port is always part of the
+// signed URL, so such URLs have no variant.
+//
+// portVariant is on the hot path, so it works on the string directly rather
+// than parsing it with url.Parse.
+func portVariant(dst []string, _ *http.Request, u string) []string {
+	scheme, rest, ok := strings.Cut(u, "://")
+	if !ok {
+		return dst
+	}
+	var def string
+	switch strings.ToLower(scheme) {
+	case "https":
+		def = ":443"
+	case "http":
+		def = ":80"
+	default:
+		return dst
+	}
+
+	// Find the host and port, skipping any userinfo.
+	hostStart := len(scheme) + len("://")
+	hostEnd := hostStart + len(rest)
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		hostEnd = hostStart + i
+	}
+	if i := strings.LastIndexByte(u[hostStart:hostEnd], '@'); i >= 0 {
+		hostStart += i + 1
+	}
+	hostport := u[hostStart:hostEnd]
+	portStart := strings.LastIndexByte(hostport, ':')
+	if portStart < strings.LastIndexByte(hostport, ']') {
+		portStart = -1 // the colon is inside an IPv6 literal
+	}
+
+	switch {
+	case portStart < 0:
+		return append(dst, u[:hostEnd]+def+u[hostEnd:])
+	case hostport[portStart:] == def:
+		return append(dst, u[:hostStart+portStart]+u[hostEnd:])
+	default:
+		return dst
+	}
+}
+
+// forwarded returns the proto and host parameters of the first element of
+// the Forwarded header, which describes the request as the first proxy saw
+// it.
+func forwarded(r *http.Request) (proto, host string) {
+	elem := firstValue(r.Header.Get("Forwarded"))
+	for _, pair := range strings.Split(elem, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(name) {
+		case "proto":
+			proto = value
+		case "host":
+			host = value
+		}
+	}
+	return proto, host
+}
+
+// forwardedProto returns the scheme in the entry of r's X-Forwarded-Proto
+// header hops places from the end, which the proxy that many hops in front
+// of the server added, or "" if there is no such entry. Entries further
+// left may have been forged by the client.
+func forwardedProto(r *http.Request, hops int) string {
+	var protos []string
+	for _, v := range r.Header.Values("X-Forwarded-Proto") {
+		protos = append(protos, strings.Split(v, ",")...)
+	}
+	if hops < 1 || len(protos) < hops {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(protos[len(protos)-hops]))
+}
+
+// firstValue returns the first element of a comma-separated header value.
+func firstValue(v string) string {
+	first, _, _ := strings.Cut(v, ",")
+	return strings.TrimSpace(first)
+}
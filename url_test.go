@@ -0,0 +1,124 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func serverRequest(target string) *http.Request {
+	r, _ := http.NewRequest("GET", target, nil)
+	r.Host = r.URL.Host
+	r.URL.Scheme, r.URL.Host = "", ""
+	return r
+}
+
+func TestRequestURL(t *testing.T) {
+	r := serverRequest("http://example.com/sms?a=b")
+	if got, want := twilio.RequestURL(r), "http://example.com/sms?a=b"; got != want {
+		t.Errorf("RequestURL = %q, want %q", got, want)
+	}
+	r.TLS = &tls.ConnectionState{}
+	if got, want := twilio.RequestURL(r), "https://example.com/sms?a=b"; got != want {
+		t.Errorf("RequestURL over TLS = %q, want %q", got, want)
+	}
+}
+
+func TestForwardedURL(t *testing.T) {
+	tests := []struct {
+		header map[string]string
+		want   string
+	}{
+		{nil, "http://internal:8080/sms?a=b"},
+		{map[string]string{
+			"X-Forwarded-Proto": "https",
+			"X-Forwarded-Host":  "example.com",
+		}, "https://example.com/sms?a=b"},
+		{map[string]string{
+			"X-Forwarded-Proto": "HTTPS, http",
+		}, "https://internal:8080/sms?a=b"},
+		{map[string]string{
+			"Forwarded":         `for=192.0.2.60;proto=https;host="example.com", for=10.0.0.1`,
+			"X-Forwarded-Proto": "http",
+		}, "https://example.com/sms?a=b"},
+	}
+	for _, test := range tests {
+		r := serverRequest("http://internal:8080/sms?a=b")
+		for k, v := range test.header {
+			r.Header.Set(k, v)
+		}
+		if got := twilio.ForwardedURL(r); got != test.want {
+			t.Errorf("ForwardedURL with %v = %q, want %q", test.header, got, test.want)
+		}
+	}
+}
+
+func TestForwardedProtoURL(t *testing.T) {
+	r := serverRequest("http://example.com/sms?a=b")
+	if got, want := twilio.ForwardedProtoURL(r), "http://example.com/sms?a=b"; got != want {
+		t.Errorf("with no headers: got %q, want %q", got, want)
+	}
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "evil.example")
+	if got, want := twilio.ForwardedProtoURL(r), "https://example.com/sms?a=b"; got != want {
+		t.Errorf("with forwarded headers: got %q, want %q", got, want)
+	}
+	// A scheme forged by the client comes before the proxy's.
+	r.Header.Set("X-Forwarded-Proto", "http, https")
+	if got, want := twilio.ForwardedProtoURL(r), "https://example.com/sms?a=b"; got != want {
+		t.Errorf("with a forged scheme: got %q, want %q", got, want)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	tests := []struct {
+		base string
+		want string
+	}{
+		{"https://example.com", "https://example.com/sms?a=b"},
+		{"https://example.com/", "https://example.com/sms?a=b"},
+		{"https://example.com:8443/twilio", "https://example.com:8443/twilio/sms?a=b"},
+	}
+	for _, test := range tests {
+		r := serverRequest("http://internal:8080/sms?a=b")
+		if got := twilio.BaseURL(test.base)(r); got != test.want {
+			t.Errorf("BaseURL(%q) = %q, want %q", test.base, got, test.want)
+		}
+	}
+}
+
+func TestBaseURLPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("BaseURL should panic for a relative URL")
+		}
+	}()
+	twilio.BaseURL("/twilio")
+}
+
+func TestValidateRequestPorts(t *testing.T) {
+	tests := []struct {
+		signed, received string
+		valid            bool
+	}{
+		{"https://example.com/sms", "https://example.com/sms", true},
+		{"https://example.com:443/sms", "https://example.com/sms", true},
+		{"https://example.com/sms", "https://example.com:443/sms", true},
+		{"http://example.com:80/sms", "http://example.com/sms", true},
+		{"https://example.com:8443/sms", "https://example.com:8443/sms", true},
+		{"https://example.com/sms", "https://example.com:8443/sms", false},
+		{"https://example.com:8443/sms", "https://example.com/sms", false},
+		{"http://example.com:443/sms", "http://example.com/sms", false},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("GET", test.received+"?a=b", nil)
+		r.Header.Set("X-Twilio-Signature", sign("12345", test.signed+"?a=b"))
+		if got := twilio.IsValid([]byte("12345"), r); got != test.valid {
+			t.Errorf("signed %s, received %s: valid = %v, want %v", test.signed, test.received, got, test.valid)
+		}
+	}
+}
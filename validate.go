@@ -1,21 +1,39 @@
 // Package twilio is a middleware library for TwiML apps.
 //
 // It makes it easy to authenticate that requests are coming from Twilio.
+//
+// Behind a load balancer or on a hosting platform, the URL a server sees is
+// usually not the one Twilio signed. Rather than working out which
+// forwarded headers to trust, pick the preset for your deployment:
+// BehindProxy, OnHeroku, OnFlyIO, OnCloudRun, OnAppEngine or OnLambda.
 package twilio
 
 import (
 	"crypto/hmac"
-	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
+	"hash"
 	"net/http"
 	"net/url"
-	"sort"
+	"slices"
 	"strings"
 )
 
 // IsValid validates that r is a genuine Twilio request rather than a spoofed
 // request from a third party.
 //
+// Requests carrying an X-Twilio-Signature-256 header are checked against
+// Twilio's HMAC-SHA256 scheme; all others are checked against the HMAC-SHA1
+// X-Twilio-Signature header. Use IsValidSHA256 to reject SHA-1 signatures.
+//
+// JSON webhooks, whose URLs carry a bodySHA256 parameter, are recognized
+// automatically. For those the body is checked against the signed hash.
+//
+// The request body is buffered in memory and r.Body is left unread, so the
+// handler can parse the form or read the raw body as usual.
+//
 // Example usage:
 //   func myTwiMLHandler(w http.ResponseWriter, r *http.Request) {
 //	if !twilio.IsValid([]byte(myTwilioAuthToken), r) {
@@ -27,30 +45,128 @@ import (
 //
 // Reference: https://www.twilio.com/docs/api/security
 func IsValid(twilioAuthToken []byte, r *http.Request) bool {
+	return ValidateRequest(twilioAuthToken, r) == nil
+}
 
-	// 1. Create a string that is your URL with the full query string.
-	s := r.URL.String()
+// IsValidSHA256 is like IsValid, but only accepts requests signed with the
+// HMAC-SHA256 scheme. Requests that only carry the older HMAC-SHA1
+// X-Twilio-Signature header are rejected.
+func IsValidSHA256(twilioAuthToken []byte, r *http.Request) bool {
+	body, err := bufferBody(r, DefaultMaxBodyBytes)
+	if err != nil {
+		return false
+	}
+	_, _, _, err = validateRequest([][]byte{twilioAuthToken}, r, body, RequestURL(r), signing{requireSHA256: true}, false)
+	return err == nil
+}
+
+// ValidateRequest is like IsValid, but reports why validation failed.
+// It returns nil if r is a genuine Twilio request. Otherwise it returns
+// ErrMissingSignature, ErrMalformedSignature, a *URLMismatchError (which
+// matches ErrSignatureMismatch) if the signature is wrong,
+// ErrBodyHashMismatch, ErrBodyTooLarge if the body is larger than
+// DefaultMaxBodyBytes, or a *FormError if the body could not be parsed.
+//
+// Example usage:
+//   if err := twilio.ValidateRequest([]byte(myTwilioAuthToken), r); err != nil {
+//	log.Printf("rejecting webhook from %s: %v", r.RemoteAddr, err)
+//	http.Error(w, "403 Forbidden", http.StatusForbidden)
+//	return
+//   }
+func ValidateRequest(twilioAuthToken []byte, r *http.Request) error {
+	return ValidateRequestURL(twilioAuthToken, r, RequestURL(r))
+}
+
+// ValidateRequestURL is like ValidateRequest, but computes the signature over
+// signedURL rather than the URL of r. Use it together with ForwardedURL, or
+// your own URLFunc, when r.URL doesn't match the URL Twilio requested.
+func ValidateRequestURL(twilioAuthToken []byte, r *http.Request, signedURL string) error {
+	body, err := bufferBody(r, DefaultMaxBodyBytes)
+	if err != nil {
+		return err
+	}
+	_, _, _, err = validateRequest([][]byte{twilioAuthToken}, r, body, signedURL, signing{}, false)
+	return err
+}
+
+// signing holds the settings that control how a request's signature is
+// computed and checked.
+type signing struct {
+	requireSHA256 bool
+	raw           bool                                        // see RawCanonicalization
+	rewrites      []func(string) string                       // see candidateURLs
+	variants      []urlVariant                                // see candidateURLs
+	macs          *[len(macHashes)]func(key []byte) hash.Hash // see WithHMAC
+	headers       [len(macHashes)]string                      // see WithSignatureHeaders
+	debug         DebugHook
+}
 
-	if r.Method == "POST" {
+// header returns the name of the header that carries signatures of the
+// given kind.
+func (sg signing) header(kind macKind) string {
+	if sg.headers[kind] != "" {
+		return sg.headers[kind]
+	}
+	if kind == macSHA256 {
+		return Signature256Header
+	}
+	return SignatureHeader
+}
 
-		// 2. Sort the list of POST variables by the parameter name.
-		r.ParseForm()
-		vals := toURLValues(r.PostForm)
-		sort.Sort(vals)
+// validateRequest checks r, whose body has been buffered by bufferBody,
+// against each of keys. It returns the index of the key that signed it, the
+// candidate URL the signature covers and, if wantForm is set, the POST form
+// it parsed along the way.
+func validateRequest(keys [][]byte, r *http.Request, body []byte, signedURL string, sg signing, wantForm bool) (int, string, url.Values, error) {
 
-		// 3. Append each POST variable, name and value, to the string with no delimiters:
-		concat := make([]string, len(vals))
-		for i := range vals {
-			concat[i] = vals[i][0] + vals[i][1]
+	// Newer Twilio products send an HMAC-SHA256 signature in
+	// X-Twilio-Signature-256. When that header is present it takes
+	// precedence; we don't fall back to SHA-1 if it fails to match.
+	kind, header := macSHA256, r.Header.Get(sg.header(macSHA256))
+	if header == "" {
+		if sg.requireSHA256 || !sg.hasMAC(macSHA1) {
+			return -1, "", nil, ErrMissingSignature
 		}
-		s += strings.Join(concat, "")
+		kind, header = macSHA1, r.Header.Get(sg.header(macSHA1))
+	}
+	if header == "" || !sg.hasMAC(kind) {
+		return -1, "", nil, ErrMissingSignature
 	}
 
-	// 4. Hash the resulting string using HMAC-SHA1, using your AuthToken as the key.
-	hash := hmac.New(sha1.New, twilioAuthToken)
-	hash.Write([]byte(s))
-	computed := hash.Sum(nil)
+	st := macStates.Get().(*macState)
+	defer macStates.Put(st)
+
+	// 1. Create a string that is your URL with the full query string.
+	//
+	// Twilio's own libraries accept a signature over the URL either with or
+	// without the default port for its scheme, so we try both, along with
+	// any other candidates the signing settings allow.
+	st.urls = sg.candidateURLs(st.urls[:0], r, signedURL)
+
+	// JSON webhooks are signed differently: Twilio adds a bodySHA256
+	// parameter with the hex SHA-256 of the body to the query string and
+	// signs the URL alone. We check the body against that hash below, once
+	// we know the URL is genuine.
+	var bodyHash string
+	if strings.Contains(r.URL.RawQuery, ParamBodySHA256) {
+		bodyHash = r.URL.Query().Get(ParamBodySHA256)
+	}
+
+	st.params = st.params[:0]
+	var form url.Values
+	if r.Method == "POST" && bodyHash == "" {
+		var err error
+		st.pairs, err = parsePairs(st.pairs[:0], r, body, sg.raw)
+		if err != nil {
+			return -1, "", nil, err
+		}
+		if wantForm {
+			form = st.pairs.values()
+		}
+		st.params = appendParams(st.params, st.pairs)
+	}
 
+	// 4. Hash the resulting string using HMAC-SHA1, using your AuthToken as the key.
 	// 5. Now take the Base64 encoding of the hash value.
 	// 6. Compare that to the hash Twilio sent in the X-Twilio-Signature HTTP header.
 	//
@@ -58,10 +174,82 @@ func IsValid(twilioAuthToken []byte, r *http.Request) bool {
 	// Twilio says to Base64 encode our hash and do a string compare to the HTTP header.
 	// Instead, we'll Base64 _decode_ the header and do a constant-time byte comparison
 	// of the MACs, to avoid timing attacks.
+	//
+	// When there are several keys, e.g. while an auth token is being rotated,
+	// we check all of them rather than stopping at the first match, so the
+	// time taken doesn't reveal which key matched.
 
-	received, _ := base64.StdEncoding.DecodeString(r.Header.Get("X-Twilio-Signature"))
+	n, err := base64.StdEncoding.Decode(grow(&st.received, base64.StdEncoding.DecodedLen(len(header))), []byte(header))
+	if err != nil {
+		return -1, "", nil, ErrMalformedSignature
+	}
+	received := st.received[:n]
 
-	return hmac.Equal(computed, received)
+	match, matchURL := -1, ""
+	for _, u := range st.urls {
+		st.buf = append(append(st.buf[:0], u...), st.params...)
+		for i, key := range keys {
+			if hmac.Equal(sg.sum(st, kind, key, st.buf), received) && match < 0 {
+				match, matchURL = i, u
+			}
+		}
+	}
+	if match < 0 {
+		if sg.debug != nil {
+			sg.debug.SignatureMismatch(r, mismatchInfo(sg.newMAC(kind), keys, st.urls, string(st.params), header))
+		}
+		return -1, "", nil, newURLMismatchError(st.urls)
+	}
+
+	if bodyHash != "" {
+		if err := checkBodyHash(body, bodyHash); err != nil {
+			return -1, "", nil, err
+		}
+	}
+	return match, matchURL, form, nil
+}
+
+// appendParams appends the part of the string Twilio signs that comes from
+// the POST parameters to buf. It sorts pairs in place.
+func appendParams(buf []byte, pairs urlValues) []byte {
+
+	// 2. Sort the list of POST variables by the parameter name.
+	//    Repeated parameters are sorted by value.
+	pairs.sort()
+
+	// 3. Append each POST variable, name and value, to the string with no delimiters:
+	for _, p := range pairs {
+		buf = append(buf, p[0]...)
+		buf = append(buf, p[1]...)
+	}
+	return buf
+}
+
+// paramString is like appendParams, but works on url.Values.
+func paramString(form url.Values) string {
+	return string(appendParams(nil, toURLValues(form)))
+}
+
+// grow returns (*b)[:n], reallocating *b if it is too small.
+func grow(b *[]byte, n int) []byte {
+	if cap(*b) < n {
+		*b = make([]byte, n)
+	}
+	return (*b)[:n]
+}
+
+// checkBodyHash checks that body hashes to bodyHash, the value of the
+// bodySHA256 query parameter.
+func checkBodyHash(body []byte, bodyHash string) error {
+	expected, err := hex.DecodeString(bodyHash)
+	if err != nil {
+		return ErrBodyHashMismatch
+	}
+	computed := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare(computed[:], expected) != 1 {
+		return ErrBodyHashMismatch
+	}
+	return nil
 }
 
 // Validate is a middleware function that validates that incoming requests
@@ -85,38 +273,75 @@ func IsValid(twilioAuthToken []byte, r *http.Request) bool {
 //
 // Reference: https://www.twilio.com/docs/api/security
 func Validate(twilioAuthToken string, protected http.HandlerFunc, authFailed ...http.HandlerFunc) http.HandlerFunc {
-	key := []byte(twilioAuthToken)
-	var invalid http.HandlerFunc
-	if authFailed == nil {
-		invalid = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			http.Error(w, "403 Forbidden", http.StatusForbidden)
-		})
-	} else {
-		invalid = authFailed[0]
-	}
-	return func(w http.ResponseWriter, r *http.Request) {
-		if IsValid(key, r) {
-			protected(w, r)
-		} else {
-			invalid(w, r)
-		}
+	var opts []Option
+	if authFailed != nil {
+		opts = append(opts, WithFailureHandler(authFailed[0]))
 	}
+	return New(twilioAuthToken, opts...).Validate(protected)
+}
+
+// ValidateHandler is like Validate, but protects an http.Handler rather than
+// an http.HandlerFunc. It is convenient for protecting a whole router.
+//
+// Example usage:
+//   mux := http.NewServeMux()
+//   mux.HandleFunc("/voice", voiceHandler)
+//   mux.HandleFunc("/sms", smsHandler)
+//   http.Handle("/twilio/", http.StripPrefix("/twilio", twilio.ValidateHandler(myAuthToken, mux)))
+func ValidateHandler(twilioAuthToken string, protected http.Handler, authFailed ...http.Handler) http.Handler {
+	var opts []Option
+	if authFailed != nil {
+		opts = append(opts, WithFailureHandler(authFailed[0]))
+	}
+	return New(twilioAuthToken, opts...).Middleware(protected)
+}
+
+// Middleware returns net/http middleware that validates incoming requests
+// using a Validator configured with opts. Requests that fail validation get
+// 403 Forbidden unless a failure handler is configured.
+//
+// Example usage with chi:
+//   r := chi.NewRouter()
+//   r.Use(twilio.Middleware(myAuthToken))
+//   r.Post("/my-twiml-path", myTwiMLHandler)
+func Middleware(twilioAuthToken string, opts ...Option) func(http.Handler) http.Handler {
+	return New(twilioAuthToken, opts...).Middleware
 }
 
 type urlValues [][2]string
 
+// toURLValues flattens v into name/value pairs. A parameter that appears
+// several times contributes one pair per value, as it does in the string
+// Twilio signs.
 func toURLValues(v url.Values) urlValues {
-	u := make(urlValues, 0, len(v))
+	n := 0
+	for _, vals := range v {
+		n += len(vals)
+	}
+	u := make(urlValues, 0, n)
 	for name, vals := range v {
-		val := ""
-		if len(vals) > 0 {
-			val = vals[0]
+		for _, val := range vals {
+			u = append(u, [2]string{name, val})
 		}
-		u = append(u, [2]string{name, val})
 	}
 	return u
 }
 
-func (u urlValues) Len() int           { return len(u) }
-func (u urlValues) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
-func (u urlValues) Less(i, j int) bool { return u[i][0] < u[j][0] }
+// values collects u into a url.Values.
+func (u urlValues) values() url.Values {
+	v := make(url.Values, len(u))
+	for _, p := range u {
+		v[p[0]] = append(v[p[0]], p[1])
+	}
+	return v
+}
+
+// sort sorts u by name, and then by value.
+func (u urlValues) sort() {
+	slices.SortFunc(u, func(a, b [2]string) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a[1], b[1])
+	})
+}
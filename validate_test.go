@@ -1,7 +1,17 @@
+//go:build !twilio_nosha1
+
 package twilio_test
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
@@ -33,3 +43,173 @@ func TestIsValid(t *testing.T) {
 		t.Error("Twilio example request should not validate with an incorrect key, but it did")
 	}
 }
+
+// exampleParams is the concatenation of the example request's sorted POST
+// parameters, as it appears in the string Twilio signs.
+const exampleParams = "CallSidCA1234567890ABCDECaller+14158675309Digits1234From+14158675309To+18005551212"
+
+func sign(key, s string) string {
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func sign256(key, s string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestIsValidSHA256(t *testing.T) {
+	// The example request signed with HMAC-SHA256 over the same string.
+	r := exampleRequest()
+	r.Header.Set("X-Twilio-Signature-256", sign256("12345", "https://mycompany.com/myapp.php?foo=1&bar=2"+exampleParams))
+	if !twilio.IsValid([]byte("12345"), r) {
+		t.Error("SHA-256 signed request should validate, but it didn't")
+	}
+
+	r = exampleRequest()
+	r.Header.Set("X-Twilio-Signature-256", sign256("55555", "https://mycompany.com/myapp.php?foo=1&bar=2"))
+	if twilio.IsValid([]byte("12345"), r) {
+		t.Error("a bad SHA-256 signature should not fall back to SHA-1, but it did")
+	}
+
+	if twilio.IsValidSHA256([]byte("12345"), exampleRequest()) {
+		t.Error("IsValidSHA256 should reject a SHA-1 only request, but it didn't")
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	if err := twilio.ValidateRequest([]byte("12345"), exampleRequest()); err != nil {
+		t.Errorf("Twilio example request should validate, got %v", err)
+	}
+
+	r := exampleRequest()
+	r.Header.Del("X-Twilio-Signature")
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != twilio.ErrMissingSignature {
+		t.Errorf("got %v, want ErrMissingSignature", err)
+	}
+
+	r = exampleRequest()
+	r.Header.Set("X-Twilio-Signature", "not base64!")
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != twilio.ErrMalformedSignature {
+		t.Errorf("got %v, want ErrMalformedSignature", err)
+	}
+
+	if err := twilio.ValidateRequest([]byte("55555"), exampleRequest()); !errors.Is(err, twilio.ErrSignatureMismatch) {
+		t.Errorf("got %v, want ErrSignatureMismatch", err)
+	}
+
+	r = exampleRequest()
+	r.Body = io.NopCloser(strings.NewReader("%zz"))
+	if _, ok := twilio.ValidateRequest([]byte("12345"), r).(*twilio.FormError); !ok {
+		t.Errorf("an unparseable body should produce a *FormError")
+	}
+}
+
+func TestIsValidJSON(t *testing.T) {
+	body := `{"EventType":"onMessageAdded","Body":"hello"}`
+	sum := sha256.Sum256([]byte(body))
+	target := "https://mycompany.com/conversations?bodySHA256=" + hex.EncodeToString(sum[:])
+
+	r, _ := http.NewRequest("POST", target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Twilio-Signature", sign("12345", target))
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != nil {
+		t.Fatalf("JSON webhook should validate, got %v", err)
+	}
+	if b, _ := io.ReadAll(r.Body); string(b) != body {
+		t.Errorf("body after validation = %q, want %q", b, body)
+	}
+
+	r, _ = http.NewRequest("POST", target, strings.NewReader(`{"EventType":"tampered"}`))
+	r.Header.Set("X-Twilio-Signature", sign("12345", target))
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != twilio.ErrBodyHashMismatch {
+		t.Errorf("got %v, want ErrBodyHashMismatch", err)
+	}
+}
+
+func TestIsValidRepeatedParams(t *testing.T) {
+	// Twilio appends every value of a repeated parameter, in sorted order.
+	r, _ := http.NewRequest("POST", "https://mycompany.com/sms", strings.NewReader(url.Values{
+		"Body":     {"hi"},
+		"MediaUrl": {"https://b.example/2.jpg", "https://a.example/1.jpg"},
+	}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature", sign("12345", "https://mycompany.com/sms"+
+		"Bodyhi"+"MediaUrlhttps://a.example/1.jpg"+"MediaUrlhttps://b.example/2.jpg"))
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != nil {
+		t.Errorf("request with repeated parameters should validate, got %v", err)
+	}
+}
+
+func TestIsValidLongToken(t *testing.T) {
+	// HMAC hashes keys longer than the hash's block size before use.
+	token := strings.Repeat("k", 100)
+	for _, header := range []string{"X-Twilio-Signature", "X-Twilio-Signature-256"} {
+		r := exampleRequest()
+		r.Header.Del("X-Twilio-Signature")
+		s := "https://mycompany.com/myapp.php?foo=1&bar=2" + exampleParams
+		if header == "X-Twilio-Signature" {
+			r.Header.Set(header, sign(token, s))
+		} else {
+			r.Header.Set(header, sign256(token, s))
+		}
+		if err := twilio.ValidateRequest([]byte(token), r); err != nil {
+			t.Errorf("%s: request signed with a long token should validate, got %v", header, err)
+		}
+	}
+}
+
+func TestValidateHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/myapp.php", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	w := httptest.NewRecorder()
+	twilio.ValidateHandler("12345", mux).ServeHTTP(w, exampleRequest())
+	if w.Code != http.StatusAccepted {
+		t.Errorf("valid request: got status %d, want 202", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	twilio.ValidateHandler("55555", mux, http.NotFoundHandler()).ServeHTTP(w, exampleRequest())
+	if w.Code != http.StatusNotFound {
+		t.Errorf("invalid request: got status %d, want the failure handler's 404", w.Code)
+	}
+}
+
+// benchmarkRequest returns a copy of the example request whose body can be
+// read again, so one request can be validated many times.
+func benchmarkRequest(b *testing.B) *http.Request {
+	r := exampleRequest()
+	if err := twilio.ValidateRequest([]byte("12345"), r); err != nil {
+		b.Fatal(err)
+	}
+	return r
+}
+
+func BenchmarkValidateRequest(b *testing.B) {
+	r := benchmarkRequest(b)
+	key := []byte("12345")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Body, _ = r.GetBody()
+		if err := twilio.ValidateRequest(key, r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidatorValidateRequest(b *testing.B) {
+	r := benchmarkRequest(b)
+	v := twilio.New("12345")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Body, _ = r.GetBody()
+		if err := v.ValidateRequest(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package twilio
+
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+// A Validator validates that requests are genuine Twilio requests. Unlike the
+// package-level functions, a Validator can be configured with Options to
+// suit the environment it runs in.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.TrustForwardedHeaders())
+//	http.HandleFunc("/my-twiml-path", v.Validate(myTwiMLHandler))
+type Validator struct {
+	keys               [][]byte
+	tokens             TokenProvider
+	url                URLFunc
+	signing            signing
+	maxBody            int64
+	methods            []string
+	requireContentType bool
+	failed             http.Handler
+	onInvalid          func(r *http.Request, err error)
+	replay             Store
+	replayWindow       time.Duration
+
+	hosts     []string
+	basicAuth *[2]string // user and password
+
+	clientCert      bool
+	clientCertNames []string
+
+	skipWarn func(r *http.Request)
+	skipIf   func(r *http.Request) bool
+}
+
+// An Option configures a Validator.
+type Option func(*Validator)
+
+// New returns a Validator that checks signatures against twilioAuthToken.
+func New(twilioAuthToken string, opts ...Option) *Validator {
+	v := &Validator{
+		keys:    [][]byte{[]byte(twilioAuthToken)},
+		url:     RequestURL,
+		maxBody: DefaultMaxBodyBytes,
+	}
+	v.failed = http.HandlerFunc(v.failure)
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// WithAdditionalTokens makes the Validator also accept requests signed with
+// any of tokens. Use it while rotating your auth token: requests signed with
+// either the old or the new token validate, and every token is checked on
+// every request so timing doesn't reveal which one matched.
+//
+// Example usage:
+//
+//	v := twilio.New(newAuthToken, twilio.WithAdditionalTokens(oldAuthToken))
+func WithAdditionalTokens(tokens ...string) Option {
+	return func(v *Validator) {
+		for _, t := range tokens {
+			v.keys = append(v.keys, []byte(t))
+		}
+	}
+}
+
+// WithURLFunc makes the Validator compute signatures over the URL returned
+// by f rather than the URL of the request.
+func WithURLFunc(f URLFunc) Option {
+	return func(v *Validator) { v.url = f }
+}
+
+// TrustForwardedHeaders makes the Validator rebuild the signed URL from
+// proxy headers. See ForwardedURL.
+func TrustForwardedHeaders() Option {
+	return WithURLFunc(ForwardedURL)
+}
+
+// WithBaseURL makes the Validator compute signatures as if requests had
+// been made to base. See BaseURL.
+func WithBaseURL(base string) Option {
+	return WithURLFunc(BaseURL(base))
+}
+
+// RequireSHA256 makes the Validator reject requests that are not signed
+// with Twilio's HMAC-SHA256 scheme. See IsValidSHA256.
+func RequireSHA256() Option {
+	return func(v *Validator) { v.signing.requireSHA256 = true }
+}
+
+// WithSignatureHeaders makes the Validator read signatures from the named
+// headers instead of SignatureHeader and Signature256Header. Use it behind
+// gateways that rename headers on their way through. An empty name leaves
+// the corresponding header unchanged.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithSignatureHeaders("X-Gw-Twilio-Signature", ""))
+func WithSignatureHeaders(sha1Header, sha256Header string) Option {
+	return func(v *Validator) {
+		v.signing.headers[macSHA1] = sha1Header
+		v.signing.headers[macSHA256] = sha256Header
+	}
+}
+
+// RawCanonicalization makes the Validator build the string it signs from
+// the request exactly as it arrived, rather than from Go's parsed view of
+// it. The path and query are taken from r.RequestURI instead of being
+// re-encoded from r.URL, and the POST body is decoded pair by pair rather
+// than by url.ParseQuery, which rejects some inputs Twilio sends (such as
+// unescaped semicolons).
+//
+// Use it when requests with unusual characters in their URLs or parameters
+// fail validation.
+func RawCanonicalization() Option {
+	return func(v *Validator) { v.signing.raw = true }
+}
+
+// SkipWhen makes the Validator pass requests for which skip returns true
+// straight through to the protected handler without validating them. Use it
+// for routes such as health checks that share a handler with your webhooks.
+// Skipped requests are tagged in their context with a Result whose Skipped
+// field is true. Calling SkipWhen more than once skips requests matched by
+// any of the predicates.
+func SkipWhen(skip func(r *http.Request) bool) Option {
+	return func(v *Validator) {
+		if prev := v.skipIf; prev != nil {
+			v.skipIf = func(r *http.Request) bool { return prev(r) || skip(r) }
+		} else {
+			v.skipIf = skip
+		}
+	}
+}
+
+// ExemptPaths makes the Validator skip requests whose URL path is exactly
+// one of paths. See SkipWhen.
+func ExemptPaths(paths ...string) Option {
+	return SkipWhen(func(r *http.Request) bool {
+		return slices.Contains(paths, r.URL.Path)
+	})
+}
+
+// WithMaxBodyBytes limits the size of request bodies the Validator will
+// read to n bytes, in place of DefaultMaxBodyBytes. Larger requests fail
+// validation with ErrBodyTooLarge before any parsing is done. A negative n
+// removes the limit.
+func WithMaxBodyBytes(n int64) Option {
+	return func(v *Validator) { v.maxBody = n }
+}
+
+// WithFailureHandler sets the handler called for requests that fail
+// validation. The default responds with 403 Forbidden, or with a more
+// specific status code for requests rejected because of their method,
+// content type or size. The handler can find out why validation failed
+// with FromContext.
+func WithFailureHandler(h http.Handler) Option {
+	return func(v *Validator) { v.failed = h }
+}
+
+// WithErrorHandler is like WithFailureHandler, but f is also passed the
+// reason validation failed, such as ErrSignatureMismatch or
+// ErrBodyTooLarge, so it can log or count failures by cause.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken, twilio.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+//		log.Printf("twilio: rejected %s %s: %v", r.Method, r.URL.Path, err)
+//		http.Error(w, "403 Forbidden", http.StatusForbidden)
+//	}))
+func WithErrorHandler(f func(w http.ResponseWriter, r *http.Request, err error)) Option {
+	return WithFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := FromContext(r.Context())
+		f(w, r, res.Err)
+	}))
+}
+
+// OnInvalid registers a function that is called with the reason whenever a
+// request fails validation, before the failure handler runs. It is intended
+// for logging and metrics.
+func OnInvalid(f func(r *http.Request, err error)) Option {
+	return func(v *Validator) { v.onInvalid = f }
+}
+
+// ValidateRequest returns nil if r is a genuine Twilio request, and
+// otherwise an error describing why it isn't. See the package-level
+// ValidateRequest.
+func (v *Validator) ValidateRequest(r *http.Request) error {
+	return v.check(r).Err
+}
+
+// Check validates r and returns the full Result, including the webhook
+// parameters of valid requests. It is intended for adapters to frameworks
+// that aren't built on net/http middleware; see StatusCode.
+func (v *Validator) Check(r *http.Request) *Result {
+	return v.check(r)
+}
+
+func (v *Validator) check(r *http.Request) *Result {
+	if v.skipWarn != nil {
+		return v.skip(r)
+	}
+	if v.skipIf != nil && v.skipIf(r) {
+		return &Result{Skipped: true, TokenIndex: -1}
+	}
+	if err := v.checkRequestLine(r); err != nil {
+		return invalid(err)
+	}
+	if v.clientCert {
+		if err := v.checkClientCert(r); err != nil {
+			return invalid(err)
+		}
+	}
+	if v.basicAuth != nil {
+		if err := v.checkBasicAuth(r); err != nil {
+			return invalid(err)
+		}
+	}
+	signedURL := v.url(r)
+	if v.hosts != nil {
+		if err := v.checkHost(signedURL); err != nil {
+			return invalid(err)
+		}
+	}
+	body, err := bufferBody(r, v.maxBody)
+	if err != nil {
+		return invalid(err)
+	}
+	// A TokenProvider may read the body, so rewind it afterwards.
+	keys, err := v.keysFor(r)
+	restoreBody(r)
+	if err != nil {
+		return invalid(err)
+	}
+	match, matchURL, form, err := validateRequest(keys, r, body, signedURL, v.signing, true)
+	if err != nil {
+		return invalid(err)
+	}
+	params := mergeParams(r.URL.Query(), form)
+	if v.replay != nil {
+		if err := v.checkReplay(r, matchURL, form, params); err != nil {
+			return invalid(err)
+		}
+	}
+	return &Result{Valid: true, TokenIndex: match, Params: params}
+}
+
+// IsValid reports whether r is a genuine Twilio request.
+func (v *Validator) IsValid(r *http.Request) bool {
+	return v.ValidateRequest(r) == nil
+}
+
+// Validate returns a handler that calls protected for genuine Twilio
+// requests and the Validator's failure handler for everything else.
+func (v *Validator) Validate(protected http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v.serve(w, r, protected)
+	}
+}
+
+// Middleware wraps next so that it only sees genuine Twilio requests. Its
+// signature is the conventional one for net/http middleware, so it can be
+// passed directly to routers such as chi or to chaining libraries such as
+// alice.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v.serve(w, r, next)
+	})
+}
+
+func (v *Validator) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	res := v.check(r)
+	r = r.WithContext(NewContext(r.Context(), res))
+	if !res.Valid && !res.Skipped {
+		if v.onInvalid != nil {
+			v.onInvalid(r, res.Err)
+		}
+		v.failed.ServeHTTP(w, r)
+		return
+	}
+	next.ServeHTTP(w, r)
+}
+
+func forbidden(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "403 Forbidden", http.StatusForbidden)
+}
@@ -0,0 +1,142 @@
+//go:build !twilio_nosha1
+
+package twilio_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+func TestValidatorValidate(t *testing.T) {
+	var logged error
+	v := twilio.New("55555", twilio.OnInvalid(func(r *http.Request, err error) { logged = err }))
+
+	w := httptest.NewRecorder()
+	v.Validate(ok)(w, exampleRequest())
+	if w.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want 403", w.Code)
+	}
+	if !errors.Is(logged, twilio.ErrSignatureMismatch) {
+		t.Errorf("OnInvalid got %v, want ErrSignatureMismatch", logged)
+	}
+
+	w = httptest.NewRecorder()
+	twilio.New("12345").Validate(ok)(w, exampleRequest())
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, want 200", w.Code)
+	}
+}
+
+func TestValidatorBaseURL(t *testing.T) {
+	// The example request, as received by a server on an internal address.
+	r := exampleRequest()
+	r.URL.Scheme, r.URL.Host, r.Host = "", "", "10.0.0.7:8080"
+
+	if twilio.New("12345").IsValid(r) {
+		t.Error("request should not validate against its internal URL")
+	}
+	if !twilio.New("12345", twilio.WithBaseURL("https://mycompany.com")).IsValid(r) {
+		t.Error("request should validate against the configured base URL")
+	}
+}
+
+func TestValidatorFailureHandler(t *testing.T) {
+	called := false
+	v := twilio.New("55555", twilio.WithFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})))
+	v.Validate(ok)(httptest.NewRecorder(), exampleRequest())
+	if !called {
+		t.Error("failure handler was not called")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	for _, test := range []struct {
+		key  string
+		want int
+	}{{"12345", http.StatusOK}, {"55555", http.StatusForbidden}} {
+		w := httptest.NewRecorder()
+		twilio.Middleware(test.key)(http.HandlerFunc(ok)).ServeHTTP(w, exampleRequest())
+		if w.Code != test.want {
+			t.Errorf("key %s: got status %d, want %d", test.key, w.Code, test.want)
+		}
+	}
+}
+
+func TestWithAdditionalTokens(t *testing.T) {
+	if !twilio.New("55555", twilio.WithAdditionalTokens("12345")).IsValid(exampleRequest()) {
+		t.Error("request signed with the additional token should validate")
+	}
+	if twilio.New("55555", twilio.WithAdditionalTokens("44444")).IsValid(exampleRequest()) {
+		t.Error("request signed with neither token should not validate")
+	}
+}
+
+func TestValidatorCheck(t *testing.T) {
+	res := twilio.New("55555", twilio.WithAdditionalTokens("12345")).Check(exampleRequest())
+	if !res.Valid || res.TokenIndex != 1 {
+		t.Errorf("got Valid %v, TokenIndex %d; want true, 1", res.Valid, res.TokenIndex)
+	}
+	if got := res.Params.Get("Digits"); got != "1234" {
+		t.Errorf("Params.Get(\"Digits\") = %q, want 1234", got)
+	}
+
+	res = twilio.New("55555").Check(exampleRequest())
+	if res.Valid || !errors.Is(res.Err, twilio.ErrSignatureMismatch) {
+		t.Errorf("got Valid %v, Err %v; want false, ErrSignatureMismatch", res.Valid, res.Err)
+	}
+}
+
+func TestSkipWhen(t *testing.T) {
+	v := twilio.New("55555",
+		twilio.ExemptPaths("/healthz"),
+		twilio.SkipWhen(func(r *http.Request) bool { return r.Header.Get("X-Internal-Test") == "1" }))
+
+	for _, test := range []struct {
+		path, header string
+		want         int
+	}{
+		{"/healthz", "", http.StatusOK},
+		{"/sms", "1", http.StatusOK},
+		{"/sms", "", http.StatusForbidden},
+	} {
+		r := httptest.NewRequest("GET", test.path, nil)
+		r.Header.Set("X-Internal-Test", test.header)
+		w := httptest.NewRecorder()
+		var skipped bool
+		v.Validate(func(w http.ResponseWriter, r *http.Request) {
+			res, _ := twilio.FromContext(r.Context())
+			skipped = res.Skipped
+		})(w, r)
+		if w.Code != test.want {
+			t.Errorf("%s %q: got status %d, want %d", test.path, test.header, w.Code, test.want)
+		}
+		if w.Code == http.StatusOK && !skipped {
+			t.Errorf("%s %q: result should be marked as skipped", test.path, test.header)
+		}
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	var got error
+	v := twilio.New("12345", twilio.WithMaxBodyBytes(16),
+		twilio.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			got = err
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	w := httptest.NewRecorder()
+	v.Validate(ok)(w, exampleRequest())
+	if got != twilio.ErrBodyTooLarge {
+		t.Errorf("error handler got %v, want ErrBodyTooLarge", got)
+	}
+	if w.Code != http.StatusTeapot {
+		t.Errorf("got status %d, want 418", w.Code)
+	}
+}
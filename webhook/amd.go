@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AnsweredBy is the result of answering machine detection: who or what
+// answered an outgoing call placed with MachineDetection enabled.
+type AnsweredBy string
+
+const (
+	AnsweredByHuman             AnsweredBy = "human"
+	AnsweredByMachineStart      AnsweredBy = "machine_start"
+	AnsweredByMachineEndBeep    AnsweredBy = "machine_end_beep"
+	AnsweredByMachineEndSilence AnsweredBy = "machine_end_silence"
+	AnsweredByMachineEndOther   AnsweredBy = "machine_end_other"
+	AnsweredByFax               AnsweredBy = "fax"
+	AnsweredByUnknown           AnsweredBy = "unknown"
+)
+
+// IsHuman reports whether a person answered.
+func (a AnsweredBy) IsHuman() bool { return a == AnsweredByHuman }
+
+// IsMachine reports whether an answering machine or voicemail answered,
+// whether or not its greeting has ended.
+func (a AnsweredBy) IsMachine() bool { return strings.HasPrefix(string(a), "machine_") }
+
+// GreetingEnded reports whether an answering machine's greeting has ended,
+// so that a message left now will be recorded from the start. It is only
+// true when detection was asked to wait for the end of the greeting, as
+// with twiml.DetectMessageEnd.
+func (a AnsweredBy) GreetingEnded() bool { return strings.HasPrefix(string(a), "machine_end_") }
+
+// IsFax reports whether a fax machine answered.
+func (a AnsweredBy) IsFax() bool { return a == AnsweredByFax }
+
+// MachineDetection holds the answering machine detection parameters, which
+// Twilio sends to the call's voice webhook, or with asynchronous detection
+// to its AsyncAmdStatusCallback.
+type MachineDetection struct {
+	AnsweredBy               AnsweredBy    `twilio:"AnsweredBy"`
+	MachineDetectionDuration time.Duration `twilio:"MachineDetectionDuration,ms"`
+}
+
+// An AMDCallback holds the parameters Twilio sends to a call's
+// AsyncAmdStatusCallback once asynchronous answering machine detection has
+// finished.
+type AMDCallback struct {
+	AccountSid string `twilio:"AccountSid"`
+	CallSid    string `twilio:"CallSid"`
+	MachineDetection
+}
+
+// DecodeAMD decodes the parameters of an asynchronous answering machine
+// detection callback request that has been validated by a
+// twilio.Validator.
+func DecodeAMD(r *http.Request) (*AMDCallback, error) {
+	return Decode[*AMDCallback](r)
+}
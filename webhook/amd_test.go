@@ -0,0 +1,60 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestAnsweredBy(t *testing.T) {
+	tests := []struct {
+		a                                  webhook.AnsweredBy
+		human, machine, greetingEnded, fax bool
+	}{
+		{webhook.AnsweredByHuman, true, false, false, false},
+		{webhook.AnsweredByMachineStart, false, true, false, false},
+		{webhook.AnsweredByMachineEndBeep, false, true, true, false},
+		{webhook.AnsweredByMachineEndSilence, false, true, true, false},
+		{webhook.AnsweredByMachineEndOther, false, true, true, false},
+		{webhook.AnsweredByFax, false, false, false, true},
+		{webhook.AnsweredByUnknown, false, false, false, false},
+	}
+	for _, test := range tests {
+		if test.a.IsHuman() != test.human || test.a.IsMachine() != test.machine ||
+			test.a.GreetingEnded() != test.greetingEnded || test.a.IsFax() != test.fax {
+			t.Errorf("%s: wrong predicates", test.a)
+		}
+	}
+}
+
+func TestDecodeAMD(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":                  {"CA123"},
+		"AnsweredBy":               {"machine_end_beep"},
+		"MachineDetectionDuration": {"4120"},
+	})
+	got, err := webhook.DecodeAMD(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.AMDCallback{
+		CallSid: "CA123",
+		MachineDetection: webhook.MachineDetection{
+			AnsweredBy:               webhook.AnsweredByMachineEndBeep,
+			MachineDetectionDuration: 4120 * time.Millisecond,
+		},
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+
+	call, err := webhook.DecodeVoice(validated(t, url.Values{"CallSid": {"CA123"}, "AnsweredBy": {"human"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !call.AnsweredBy.IsHuman() {
+		t.Errorf("VoiceRequest.AnsweredBy = %q, want human", call.AnsweredBy)
+	}
+}
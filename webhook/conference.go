@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// A ConferenceEvent is the kind of conference event a
+// ConferenceStatusCallback reports.
+type ConferenceEvent string
+
+const (
+	ConferenceStart            ConferenceEvent = "conference-start"
+	ConferenceEnd              ConferenceEvent = "conference-end"
+	ParticipantJoin            ConferenceEvent = "participant-join"
+	ParticipantLeave           ConferenceEvent = "participant-leave"
+	ParticipantMute            ConferenceEvent = "participant-mute"
+	ParticipantUnmute          ConferenceEvent = "participant-unmute"
+	ParticipantHold            ConferenceEvent = "participant-hold"
+	ParticipantUnhold          ConferenceEvent = "participant-unhold"
+	ParticipantModify          ConferenceEvent = "participant-modify"
+	ParticipantSpeechStart     ConferenceEvent = "participant-speech-start"
+	ParticipantSpeechStop      ConferenceEvent = "participant-speech-stop"
+	ConferenceAnnouncementEnd  ConferenceEvent = "announcement-end"
+	ConferenceAnnouncementFail ConferenceEvent = "announcement-fail"
+)
+
+// IsParticipantEvent reports whether e is about a single participant, in
+// which case the participant fields of the callback are set.
+func (e ConferenceEvent) IsParticipantEvent() bool {
+	return strings.HasPrefix(string(e), "participant-")
+}
+
+// A ConferenceStatusCallback holds the parameters Twilio sends to a
+// Conference's StatusCallback for each of the StatusCallbackEvents it
+// subscribes to. Switch on StatusCallbackEvent to tell them apart.
+//
+// Example usage:
+//
+//	ev, err := webhook.DecodeConferenceStatus(r)
+//	...
+//	switch ev.StatusCallbackEvent {
+//	case webhook.ParticipantJoin:
+//		log.Printf("%s joined %s", ev.ParticipantLabel, ev.FriendlyName)
+//	case webhook.ConferenceEnd:
+//		log.Printf("%s ended: %s", ev.FriendlyName, ev.ReasonConferenceEnded)
+//	}
+type ConferenceStatusCallback struct {
+	AccountSid          string          `twilio:"AccountSid"`
+	ConferenceSid       string          `twilio:"ConferenceSid"`
+	FriendlyName        string          `twilio:"FriendlyName"`
+	StatusCallbackEvent ConferenceEvent `twilio:"StatusCallbackEvent"`
+	Timestamp           time.Time       `twilio:"Timestamp"`
+	SequenceNumber      int             `twilio:"SequenceNumber"`
+
+	// Set for participant events.
+	CallSid                string `twilio:"CallSid"`
+	ParticipantLabel       string `twilio:"ParticipantLabel"`
+	Muted                  bool   `twilio:"Muted"`
+	Hold                   bool   `twilio:"Hold"`
+	Coaching               bool   `twilio:"Coaching"`
+	CallSidToCoach         string `twilio:"CallSidToCoach"`
+	EndConferenceOnExit    bool   `twilio:"EndConferenceOnExit"`
+	StartConferenceOnEnter bool   `twilio:"StartConferenceOnEnter"`
+
+	// Set for conference-end.
+	ReasonConferenceEnded            string `twilio:"ReasonConferenceEnded"`
+	CallSidEndingConference          string `twilio:"CallSidEndingConference"`
+	ParticipantLabelEndingConference string `twilio:"ParticipantLabelEndingConference"`
+
+	// Set for announcement events, and when the conference is recorded.
+	Reason            string        `twilio:"Reason"`
+	RecordingURL      string        `twilio:"RecordingUrl"`
+	RecordingDuration time.Duration `twilio:"RecordingDuration"`
+}
+
+// DecodeConferenceStatus decodes the parameters of a conference status
+// callback request that has been validated by a twilio.Validator.
+func DecodeConferenceStatus(r *http.Request) (*ConferenceStatusCallback, error) {
+	return Decode[*ConferenceStatusCallback](r)
+}
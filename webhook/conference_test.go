@@ -0,0 +1,53 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeConferenceStatus(t *testing.T) {
+	r := validated(t, url.Values{
+		"AccountSid":             {"AC456"},
+		"ConferenceSid":          {"CF123"},
+		"FriendlyName":           {"standup"},
+		"StatusCallbackEvent":    {"participant-join"},
+		"Timestamp":              {"Tue, 07 Nov 2023 19:50:55 +0000"},
+		"SequenceNumber":         {"2"},
+		"CallSid":                {"CA789"},
+		"ParticipantLabel":       {"host"},
+		"Muted":                  {"false"},
+		"Hold":                   {"false"},
+		"Coaching":               {"false"},
+		"EndConferenceOnExit":    {"true"},
+		"StartConferenceOnEnter": {"true"},
+	})
+	got, err := webhook.DecodeConferenceStatus(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.ConferenceStatusCallback{
+		AccountSid:             "AC456",
+		ConferenceSid:          "CF123",
+		FriendlyName:           "standup",
+		StatusCallbackEvent:    webhook.ParticipantJoin,
+		Timestamp:              time.Date(2023, 11, 7, 19, 50, 55, 0, time.UTC),
+		SequenceNumber:         2,
+		CallSid:                "CA789",
+		ParticipantLabel:       "host",
+		EndConferenceOnExit:    true,
+		StartConferenceOnEnter: true,
+	}
+	if !got.Timestamp.Equal(want.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, want.Timestamp)
+	}
+	got.Timestamp = want.Timestamp
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+	if !got.StatusCallbackEvent.IsParticipantEvent() || webhook.ConferenceEnd.IsParticipantEvent() {
+		t.Error("IsParticipantEvent is wrong")
+	}
+}
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// A ConversationEventType is the kind of event a Conversations service
+// webhook reports. The On...Add and On...Remove forms without a trailing
+// "ed" are pre-event webhooks, sent before the change is made; the rest are
+// post-event webhooks, sent after.
+type ConversationEventType string
+
+const (
+	OnConversationAdd          ConversationEventType = "onConversationAdd"
+	OnConversationAdded        ConversationEventType = "onConversationAdded"
+	OnConversationUpdate       ConversationEventType = "onConversationUpdate"
+	OnConversationUpdated      ConversationEventType = "onConversationUpdated"
+	OnConversationRemove       ConversationEventType = "onConversationRemove"
+	OnConversationRemoved      ConversationEventType = "onConversationRemoved"
+	OnConversationStateUpdated ConversationEventType = "onConversationStateUpdated"
+	OnMessageAdd               ConversationEventType = "onMessageAdd"
+	OnMessageAdded             ConversationEventType = "onMessageAdded"
+	OnMessageUpdate            ConversationEventType = "onMessageUpdate"
+	OnMessageUpdated           ConversationEventType = "onMessageUpdated"
+	OnMessageRemove            ConversationEventType = "onMessageRemove"
+	OnMessageRemoved           ConversationEventType = "onMessageRemoved"
+	OnParticipantAdd           ConversationEventType = "onParticipantAdd"
+	OnParticipantAdded         ConversationEventType = "onParticipantAdded"
+	OnParticipantUpdate        ConversationEventType = "onParticipantUpdate"
+	OnParticipantUpdated       ConversationEventType = "onParticipantUpdated"
+	OnParticipantRemove        ConversationEventType = "onParticipantRemove"
+	OnParticipantRemoved       ConversationEventType = "onParticipantRemoved"
+	OnDeliveryUpdated          ConversationEventType = "onDeliveryUpdated"
+	OnUserAdded                ConversationEventType = "onUserAdded"
+	OnUserUpdated              ConversationEventType = "onUserUpdated"
+)
+
+// A ConversationEvent holds the parameters of a Conversations service
+// webhook. Which fields are set depends on EventType: message events set
+// the message fields, participant events the participant fields, and so
+// on. Attributes holds JSON, for json.Unmarshal into a type of your own.
+type ConversationEvent struct {
+	EventType      ConversationEventType `twilio:"EventType"`
+	AccountSid     string                `twilio:"AccountSid"`
+	ChatServiceSid string                `twilio:"ChatServiceSid"`
+	Source         string                `twilio:"Source"` // SDK, API or SMS, for example
+	ClientIdentity string                `twilio:"ClientIdentity"`
+	RetryCount     int                   `twilio:"RetryCount"`
+
+	ConversationSid string    `twilio:"ConversationSid"`
+	FriendlyName    string    `twilio:"FriendlyName"`
+	UniqueName      string    `twilio:"UniqueName"`
+	State           string    `twilio:"State"` // active, inactive or closed
+	StateFrom       string    `twilio:"StateFrom"`
+	StateTo         string    `twilio:"StateTo"`
+	DateCreated     time.Time `twilio:"DateCreated"`
+	DateUpdated     time.Time `twilio:"DateUpdated"`
+
+	MessageSid string `twilio:"MessageSid"`
+	Index      int    `twilio:"Index"`
+	Author     string `twilio:"Author"`
+	Body       string `twilio:"Body"`
+	Media      string `twilio:"Media"` // JSON list of media
+
+	ParticipantSid   string `twilio:"ParticipantSid"`
+	Identity         string `twilio:"Identity"`
+	RoleSid          string `twilio:"RoleSid"`
+	MessagingBinding string `twilio:"MessagingBinding"` // JSON object
+
+	Attributes string `twilio:"Attributes"`
+}
+
+// DecodeConversationEvent decodes a Conversations service webhook request
+// that has been validated by a twilio.Validator. Conversations sends its
+// webhooks as forms, or as JSON whose SHA-256 hash is in the signed URL's
+// bodySHA256 parameter; both are accepted. JSON objects and lists, such as
+// Attributes, are left as JSON in their string fields.
+func DecodeConversationEvent(r *http.Request) (*ConversationEvent, error) {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct != "application/json" {
+		return Decode[*ConversationEvent](r)
+	}
+	var body map[string]any
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, err
+	}
+	p := make(url.Values, len(body))
+	for k, v := range body {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			p.Set(k, v)
+		case json.Number:
+			p.Set(k, v.String())
+		case bool:
+			p.Set(k, strconv.FormatBool(v))
+		default:
+			b, _ := json.Marshal(v)
+			p.Set(k, string(b))
+		}
+	}
+	ev := new(ConversationEvent)
+	if err := decode(p, reflect.ValueOf(ev).Elem(), ""); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// A ConversationsHandler dispatches Conversations service webhooks to
+// functions registered for their event types. Events with no function
+// registered are acknowledged with 200 OK.
+//
+// For pre-event webhooks, a function can reject the change by responding
+// with a status other than 200, or modify it by responding with JSON
+// holding the fields to change.
+//
+// Example usage:
+//
+//	h := new(webhook.ConversationsHandler)
+//	h.HandleFunc(webhook.OnMessageAdded, func(w http.ResponseWriter, r *http.Request, ev *webhook.ConversationEvent) {
+//		log.Printf("%s in %s: %s", ev.Author, ev.ConversationSid, ev.Body)
+//	})
+//	http.Handle("/conversations", v.Middleware(h))
+type ConversationsHandler struct {
+	handlers map[ConversationEventType]func(http.ResponseWriter, *http.Request, *ConversationEvent)
+}
+
+// HandleFunc registers f for events of type event, replacing any function
+// registered for it before.
+func (h *ConversationsHandler) HandleFunc(event ConversationEventType, f func(w http.ResponseWriter, r *http.Request, ev *ConversationEvent)) {
+	if h.handlers == nil {
+		h.handlers = make(map[ConversationEventType]func(http.ResponseWriter, *http.Request, *ConversationEvent))
+	}
+	h.handlers[event] = f
+}
+
+// ServeHTTP decodes the event in r and calls the function registered for
+// its type. Requests that can't be decoded get 400 Bad Request.
+func (h *ConversationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ev, err := DecodeConversationEvent(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f := h.handlers[ev.EventType]; f != nil {
+		f(w, r, ev)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
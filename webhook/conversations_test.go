@@ -0,0 +1,124 @@
+package webhook_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+// serveJSON sends body to h as a signed JSON webhook behind a
+// twilio.Validator, and returns the response.
+func serveJSON(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	sum := sha256.Sum256([]byte(body))
+	target := "https://example.com/conversations?bodySHA256=" + hex.EncodeToString(sum[:])
+	r := httptest.NewRequest("POST", target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), target, nil))
+	w := httptest.NewRecorder()
+	twilio.New("12345").Middleware(h).ServeHTTP(w, r)
+	return w
+}
+
+func TestDecodeConversationEventJSON(t *testing.T) {
+	var got *webhook.ConversationEvent
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if got, err = webhook.DecodeConversationEvent(r); err != nil {
+			t.Fatal(err)
+		}
+	})
+	serveJSON(t, h, `{"EventType":"onMessageAdded","ConversationSid":"CH123","MessageSid":"IM123",`+
+		`"Index":1000000,"RetryCount":"1","Author":"alice","Body":"hello","Attributes":{"urgent":true,"ticket":12345678},"RoleSid":null}`)
+	if got == nil {
+		t.Fatal("request failed validation")
+	}
+	want := webhook.ConversationEvent{
+		EventType:       webhook.OnMessageAdded,
+		ConversationSid: "CH123",
+		MessageSid:      "IM123",
+		Index:           1000000,
+		RetryCount:      1,
+		Author:          "alice",
+		Body:            "hello",
+		Attributes:      `{"ticket":12345678,"urgent":true}`,
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeConversationEventForm(t *testing.T) {
+	ev, err := webhook.DecodeConversationEvent(validated(t, url.Values{
+		"EventType":      {"onParticipantAdded"},
+		"ParticipantSid": {"MB123"},
+		"Identity":       {"bob"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.EventType != webhook.OnParticipantAdded || ev.ParticipantSid != "MB123" || ev.Identity != "bob" {
+		t.Errorf("got %+v", ev)
+	}
+}
+
+func TestDecodeConversationEventUnsigned(t *testing.T) {
+	// Without bodySHA256, the signature covers the URL but not the body.
+	const target = "https://example.com/conversations"
+	r := httptest.NewRequest("POST", target, strings.NewReader(`{"EventType":"onMessageAdded"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), target, nil))
+	var err error
+	twilio.New("12345").Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, err = webhook.DecodeConversationEvent(r)
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	if !errors.Is(err, webhook.ErrUnsignedBody) {
+		t.Errorf("got %v, want ErrUnsignedBody", err)
+	}
+}
+
+func TestConversationsHandler(t *testing.T) {
+	var h webhook.ConversationsHandler
+	h.HandleFunc(webhook.OnMessageAdd, func(w http.ResponseWriter, r *http.Request, ev *webhook.ConversationEvent) {
+		if strings.Contains(ev.Body, "spam") {
+			http.Error(w, "rejected", http.StatusForbidden)
+		}
+	})
+
+	if w := serveJSON(t, &h, `{"EventType":"onMessageAdd","Body":"spam"}`); w.Code != http.StatusForbidden {
+		t.Errorf("onMessageAdd: got status %d, want 403", w.Code)
+	}
+	if w := serveJSON(t, &h, `{"EventType":"onMessageAdd","Body":"hi"}`); w.Code != http.StatusOK {
+		t.Errorf("onMessageAdd: got status %d, want 200", w.Code)
+	}
+	if w := serveJSON(t, &h, `{"EventType":"onUserAdded"}`); w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("unhandled event: got status %d and body %q, want 200 and no body", w.Code, w.Body)
+	}
+	if w := serveJSON(t, &h, `not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("bad body: got status %d, want 400", w.Code)
+	}
+}
+
+func TestDecodeConversationEventSkipped(t *testing.T) {
+	r := httptest.NewRequest("POST", "/conversations", strings.NewReader(`{"EventType":"onMessageAdded","Body":"hi"}`))
+	r.Header.Set("Content-Type", "application/json")
+	var ev *webhook.ConversationEvent
+	var err error
+	twilio.New("12345", twilio.InsecureSkipValidation(func(*http.Request) {})).Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ev, err = webhook.DecodeConversationEvent(r)
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.EventType != webhook.OnMessageAdded || ev.Body != "hi" {
+		t.Errorf("got %+v", ev)
+	}
+}
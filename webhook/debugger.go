@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/errorcode"
+)
+
+// A DebuggerLevel is the severity of a Debugger event.
+type DebuggerLevel string
+
+const (
+	DebuggerError   DebuggerLevel = "ERROR"
+	DebuggerWarning DebuggerLevel = "WARNING"
+)
+
+// A DebuggerEvent holds the parameters of a Debugger webhook, which
+// Twilio sends for each error or warning in an account's Debugger, such as
+// a webhook of yours that timed out or returned invalid TwiML.
+//
+// Example usage:
+//
+//	ev, err := webhook.DecodeDebugger(r)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	if ev.Level == webhook.DebuggerError {
+//		alert("Twilio error %v on %s: %s", ev.Payload.ErrorCode, ev.Payload.ResourceSid, ev.Payload.MoreInfo["Msg"])
+//	}
+type DebuggerEvent struct {
+	Sid              string          `twilio:"Sid"`
+	AccountSid       string          `twilio:"AccountSid"`
+	ParentAccountSid string          `twilio:"ParentAccountSid"`
+	Timestamp        time.Time       `twilio:"Timestamp"`
+	Level            DebuggerLevel   `twilio:"Level"`
+	PayloadType      string          `twilio:"PayloadType"`
+	Payload          DebuggerPayload `twilio:"Payload"`
+}
+
+// A DebuggerPayload describes the error or warning a Debugger event
+// reports. Request and Response are set for errors in requests Twilio made
+// to a webhook.
+type DebuggerPayload struct {
+	ResourceSid string         // the call, message or other resource affected
+	ServiceSid  string         // its service, if it has one
+	ErrorCode   errorcode.Code // such as 11200, HTTP retrieval failure
+	MoreInfo    map[string]any // details that depend on the error, such as Msg
+	Request     *DebuggerRequest
+	Response    *DebuggerResponse
+}
+
+// A DebuggerRequest is a webhook request Twilio made that failed.
+type DebuggerRequest struct {
+	URL        string
+	Method     string
+	Headers    map[string]string
+	Parameters map[string]string
+}
+
+// A DebuggerResponse is the response Twilio got to a webhook request that
+// failed, if it got one.
+type DebuggerResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       string
+}
+
+// UnmarshalText decodes the JSON payload of a Debugger event.
+func (p *DebuggerPayload) UnmarshalText(text []byte) error {
+	var aux struct {
+		ResourceSid string         `json:"resource_sid"`
+		ServiceSid  string         `json:"service_sid"`
+		ErrorCode   json.Number    `json:"error_code"`
+		MoreInfo    map[string]any `json:"more_info"`
+		Webhook     struct {
+			Request *struct {
+				URL        string            `json:"url"`
+				Method     string            `json:"method"`
+				Headers    map[string]string `json:"headers"`
+				Parameters map[string]string `json:"parameters"`
+			} `json:"request"`
+			Response *struct {
+				StatusCode json.Number       `json:"status_code"`
+				Headers    map[string]string `json:"headers"`
+				Body       string            `json:"body"`
+			} `json:"response"`
+		} `json:"webhook"`
+	}
+	if err := json.Unmarshal(text, &aux); err != nil {
+		return err
+	}
+	*p = DebuggerPayload{ResourceSid: aux.ResourceSid, ServiceSid: aux.ServiceSid, MoreInfo: aux.MoreInfo}
+	if aux.ErrorCode != "" {
+		n, err := strconv.Atoi(aux.ErrorCode.String())
+		if err != nil {
+			return err
+		}
+		p.ErrorCode = errorcode.Code(n)
+	}
+	if req := aux.Webhook.Request; req != nil {
+		p.Request = &DebuggerRequest{URL: req.URL, Method: req.Method, Headers: req.Headers, Parameters: req.Parameters}
+	}
+	if resp := aux.Webhook.Response; resp != nil {
+		p.Response = &DebuggerResponse{Headers: resp.Headers, Body: resp.Body}
+		if resp.StatusCode != "" {
+			n, err := strconv.Atoi(resp.StatusCode.String())
+			if err != nil {
+				return err
+			}
+			p.Response.StatusCode = n
+		}
+	}
+	return nil
+}
+
+// DecodeDebugger decodes the parameters of a Debugger webhook request that
+// has been validated by a twilio.Validator.
+func DecodeDebugger(r *http.Request) (*DebuggerEvent, error) {
+	return Decode[*DebuggerEvent](r)
+}
@@ -0,0 +1,67 @@
+package webhook_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeDebugger(t *testing.T) {
+	r := validated(t, url.Values{
+		"Sid":         {"NO123"},
+		"AccountSid":  {"AC456"},
+		"Timestamp":   {"2024-05-01T12:00:00Z"},
+		"Level":       {"ERROR"},
+		"PayloadType": {"application/json"},
+		"Payload": {`{"resource_sid":"CA789","service_sid":null,"error_code":"11200",` +
+			`"more_info":{"Msg":"Connection refused","sourceComponent":"12000"},` +
+			`"webhook":{"type":"application/json",` +
+			`"request":{"url":"https://example.com/voice","method":"POST","headers":{},"parameters":{"CallSid":"CA789"}},` +
+			`"response":{"status_code":502,"headers":{"Content-Type":"text/html"},"body":"Bad Gateway"}}}`},
+	})
+	got, err := webhook.DecodeDebugger(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.DebuggerEvent{
+		Sid:         "NO123",
+		AccountSid:  "AC456",
+		Timestamp:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Level:       webhook.DebuggerError,
+		PayloadType: "application/json",
+		Payload: webhook.DebuggerPayload{
+			ResourceSid: "CA789",
+			ErrorCode:   11200,
+			MoreInfo:    map[string]any{"Msg": "Connection refused", "sourceComponent": "12000"},
+			Request: &webhook.DebuggerRequest{
+				URL:        "https://example.com/voice",
+				Method:     "POST",
+				Headers:    map[string]string{},
+				Parameters: map[string]string{"CallSid": "CA789"},
+			},
+			Response: &webhook.DebuggerResponse{
+				StatusCode: 502,
+				Headers:    map[string]string{"Content-Type": "text/html"},
+				Body:       "Bad Gateway",
+			},
+		},
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+
+	r = validated(t, url.Values{"Level": {"WARNING"}, "Payload": {`{"error_code":13227}`}})
+	if got, err = webhook.DecodeDebugger(r); err != nil {
+		t.Fatal(err)
+	}
+	if got.Payload.ErrorCode != 13227 || got.Payload.Request != nil || got.Payload.Response != nil {
+		t.Errorf("got payload %+v", got.Payload)
+	}
+
+	if _, err := webhook.DecodeDebugger(validated(t, url.Values{"Payload": {"not json"}})); err == nil {
+		t.Error("got no error for a bad payload")
+	}
+}
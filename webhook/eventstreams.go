@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// A CloudEvent is an event delivered by Event Streams to a webhook sink,
+// in the CloudEvents 1.0 envelope. Type names the event, such as
+// com.twilio.messaging.message.delivered, and Data holds its payload, whose
+// fields are described by DataSchema.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	ID              string          `json:"id"`
+	Subject         string          `json:"subject,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Time            time.Time       `json:"time"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// DecodeData unmarshals e's payload into v.
+//
+// Example usage:
+//
+//	var msg struct {
+//		MessageSid    string `json:"messageSid"`
+//		MessageStatus string `json:"messageStatus"`
+//	}
+//	if err := ev.DecodeData(&msg); err != nil {
+//		return err
+//	}
+func (e *CloudEvent) DecodeData(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("webhook: event %s: %w", e.ID, err)
+	}
+	return nil
+}
+
+// DecodeEvents decodes the events in an Event Streams webhook sink request
+// that has been validated by a twilio.Validator. Sinks send events in
+// batches, as a JSON list; a single event object is accepted too. The
+// body's hash must be in the signed URL's bodySHA256 parameter, so that
+// the signature covers the events.
+func DecodeEvents(r *http.Request) ([]*CloudEvent, error) {
+	var raw json.RawMessage
+	if err := decodeJSON(r, &raw); err != nil {
+		return nil, err
+	}
+	if b := bytes.TrimSpace(raw); len(b) > 0 && b[0] == '{' {
+		ev := new(CloudEvent)
+		if err := json.Unmarshal(b, ev); err != nil {
+			return nil, fmt.Errorf("webhook: %w", err)
+		}
+		return []*CloudEvent{ev}, nil
+	}
+	var events []*CloudEvent
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, fmt.Errorf("webhook: %w", err)
+	}
+	return events, nil
+}
+
+// An EventStreamHandler is an Event Streams webhook sink. It dispatches
+// each event in a request to the function registered for its type, and
+// ignores events with none.
+//
+// If a function returns an error, the handler stops and responds with 500
+// Internal Server Error, and Event Streams delivers the whole batch again
+// later. Functions should therefore be idempotent, and can use the events'
+// IDs to skip ones they have already handled.
+//
+// Example usage:
+//
+//	h := new(webhook.EventStreamHandler)
+//	h.HandleFunc("com.twilio.messaging.message.failed", func(r *http.Request, ev *webhook.CloudEvent) error {
+//		log.Printf("message failed: %s", ev.Data)
+//		return nil
+//	})
+//	http.Handle("/events", v.Middleware(h))
+type EventStreamHandler struct {
+	handlers map[string]func(*http.Request, *CloudEvent) error
+}
+
+// HandleFunc registers f for events of type eventType, replacing any
+// function registered for it before.
+func (h *EventStreamHandler) HandleFunc(eventType string, f func(r *http.Request, ev *CloudEvent) error) {
+	if h.handlers == nil {
+		h.handlers = make(map[string]func(*http.Request, *CloudEvent) error)
+	}
+	h.handlers[eventType] = f
+}
+
+// ServeHTTP decodes the events in r and calls the functions registered
+// for their types, in order. Requests that can't be decoded get 400 Bad
+// Request.
+func (h *EventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	events, err := DecodeEvents(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, ev := range events {
+		if f := h.handlers[ev.Type]; f != nil {
+			if err := f(r, ev); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
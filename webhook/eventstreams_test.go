@@ -0,0 +1,98 @@
+package webhook_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+const eventBatch = `[
+	{"specversion":"1.0","type":"com.twilio.messaging.message.delivered","source":"/2010-04-01/Accounts/AC123/Messages/SM1.json",
+	 "id":"EZ1","dataschema":"https://events-schemas.twilio.com/Messaging.MessageStatus/2","datacontenttype":"application/json",
+	 "time":"2024-05-01T12:00:00.000Z","data":{"messageSid":"SM1","messageStatus":"DELIVERED"}},
+	{"specversion":"1.0","type":"com.twilio.messaging.message.failed","id":"EZ2","time":"2024-05-01T12:00:01.000Z",
+	 "data":{"messageSid":"SM2","messageStatus":"FAILED","errorCode":30003}}
+]`
+
+func TestDecodeEvents(t *testing.T) {
+	var events []*webhook.CloudEvent
+	serveJSON(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if events, err = webhook.DecodeEvents(r); err != nil {
+			t.Fatal(err)
+		}
+	}), eventBatch)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	ev := events[0]
+	if ev.Type != "com.twilio.messaging.message.delivered" || ev.ID != "EZ1" || ev.SpecVersion != "1.0" ||
+		ev.DataSchema != "https://events-schemas.twilio.com/Messaging.MessageStatus/2" ||
+		!ev.Time.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v", ev)
+	}
+	var data struct {
+		MessageSid string `json:"messageSid"`
+		ErrorCode  int    `json:"errorCode"`
+	}
+	if err := events[1].DecodeData(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.MessageSid != "SM2" || data.ErrorCode != 30003 {
+		t.Errorf("got data %+v", data)
+	}
+
+	serveJSON(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if events, err = webhook.DecodeEvents(r); err != nil {
+			t.Fatal(err)
+		}
+	}), ` {"type":"com.twilio.voice.status-callback.call.completed","id":"EZ3"}`)
+	if len(events) != 1 || events[0].ID != "EZ3" {
+		t.Errorf("single event: got %v", events)
+	}
+}
+
+func TestDecodeEventsUnsigned(t *testing.T) {
+	const target = "https://example.com/events"
+	r := httptest.NewRequest("POST", target, strings.NewReader(eventBatch))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), target, nil))
+	var err error
+	twilio.New("12345").Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, err = webhook.DecodeEvents(r)
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	if !errors.Is(err, webhook.ErrUnsignedBody) {
+		t.Errorf("got %v, want ErrUnsignedBody", err)
+	}
+}
+
+func TestEventStreamHandler(t *testing.T) {
+	var h webhook.EventStreamHandler
+	var failed []string
+	h.HandleFunc("com.twilio.messaging.message.failed", func(r *http.Request, ev *webhook.CloudEvent) error {
+		failed = append(failed, ev.ID)
+		return nil
+	})
+	if w := serveJSON(t, &h, eventBatch); w.Code != http.StatusOK || len(failed) != 1 || failed[0] != "EZ2" {
+		t.Errorf("got status %d and failed %v", w.Code, failed)
+	}
+
+	h.HandleFunc("com.twilio.messaging.message.delivered", func(r *http.Request, ev *webhook.CloudEvent) error {
+		return errors.New("database unavailable")
+	})
+	failed = nil
+	if w := serveJSON(t, &h, eventBatch); w.Code != http.StatusInternalServerError || failed != nil {
+		t.Errorf("failing function: got status %d and failed %v", w.Code, failed)
+	}
+
+	if w := serveJSON(t, &h, `{"type":`); w.Code != http.StatusBadRequest {
+		t.Errorf("bad body: got status %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import "strings"
+
+// Geo is what Twilio knows of where a phone number is: the city, state or
+// province, postal code and country it was issued in. It is not where the
+// caller is, and fields are often empty, especially for mobile numbers.
+type Geo struct {
+	City    string  `twilio:"City"`
+	State   string  `twilio:"State"`
+	Zip     string  `twilio:"Zip"`
+	Country Country `twilio:"Country"`
+}
+
+// Country is an ISO 3166-1 alpha-2 country code, such as US or GB. It is
+// decoded in upper case, whatever case it is sent in.
+type Country string
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Country) UnmarshalText(text []byte) error {
+	*c = Country(strings.ToUpper(strings.TrimSpace(string(text))))
+	return nil
+}
+
+// Valid reports whether c has the form of an ISO 3166-1 alpha-2 code: two
+// letters.
+func (c Country) Valid() bool {
+	return len(c) == 2 && 'A' <= c[0] && c[0] <= 'Z' && 'A' <= c[1] && c[1] <= 'Z'
+}
+
+// Is reports whether c is the country with the code code, ignoring case.
+//
+// Example usage:
+//
+//	if call.FromGeo.Country.Is("US") {
+//		...
+//	}
+func (c Country) Is(code string) bool {
+	return strings.EqualFold(string(c), code)
+}
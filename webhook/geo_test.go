@@ -0,0 +1,50 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestGeo(t *testing.T) {
+	r := validated(t, url.Values{
+		"From":        {"+14155550199"},
+		"FromCity":    {"SAN FRANCISCO"},
+		"FromState":   {"CA"},
+		"FromZip":     {"94105"},
+		"FromCountry": {"us"},
+		"To":          {"+442079460000"},
+		"ToCountry":   {"GB"},
+	})
+	call, err := webhook.DecodeVoice(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.Geo{City: "SAN FRANCISCO", State: "CA", Zip: "94105", Country: "US"}
+	if call.FromGeo != want {
+		t.Errorf("FromGeo = %+v, want %+v", call.FromGeo, want)
+	}
+	if call.From != "+14155550199" {
+		t.Errorf("From = %q", call.From)
+	}
+	if want := (webhook.Geo{Country: "GB"}); call.ToGeo != want {
+		t.Errorf("ToGeo = %+v, want %+v", call.ToGeo, want)
+	}
+
+	msg, err := webhook.DecodeMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !msg.FromGeo.Country.Is("us") || msg.ToGeo.Country != "GB" {
+		t.Errorf("message geo: got %+v and %+v", msg.FromGeo, msg.ToGeo)
+	}
+}
+
+func TestCountryValid(t *testing.T) {
+	for c, want := range map[webhook.Country]bool{"US": true, "GB": true, "": false, "us": false, "USA": false, "U1": false} {
+		if got := c.Valid(); got != want {
+			t.Errorf("Country(%q).Valid() = %v, want %v", c, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/errorcode"
+)
+
+// A MessageRequest holds the parameters Twilio sends to a messaging
+// webhook when a message comes in.
+type MessageRequest struct {
+	MessageSid          string        `twilio:"MessageSid"`
+	SmsSid              string        `twilio:"SmsSid"`        // deprecated; same as MessageSid
+	SmsMessageSid       string        `twilio:"SmsMessageSid"` // deprecated; same as MessageSid
+	AccountSid          string        `twilio:"AccountSid"`
+	MessagingServiceSid string        `twilio:"MessagingServiceSid"`
+	From                PhoneNumber   `twilio:"From"`
+	To                  PhoneNumber   `twilio:"To"`
+	Body                string        `twilio:"Body"`
+	NumMedia            int           `twilio:"NumMedia"`
+	NumSegments         int           `twilio:"NumSegments"`
+	SmsStatus           MessageStatus `twilio:"SmsStatus"`
+	APIVersion          string        `twilio:"ApiVersion"`
+	FromGeo             Geo           `twilio:"From"`
+	ToGeo               Geo           `twilio:"To"`
+
+	// WhatsApp is set for messages that come in over WhatsApp.
+	WhatsApp
+
+	media []MediaItem
+}
+
+// A MediaItem is a file attached to a message.
+type MediaItem struct {
+	URL         string // where to download it from
+	ContentType string // its MIME type, such as image/jpeg
+}
+
+// Media returns the files attached to the message, which Twilio sends as
+// NumMedia numbered pairs of MediaUrl and MediaContentType parameters.
+//
+// Example usage:
+//
+//	for _, m := range msg.Media() {
+//		if strings.HasPrefix(m.ContentType, "image/") {
+//			saveImage(m.URL)
+//		}
+//	}
+func (m *MessageRequest) Media() []MediaItem {
+	return append([]MediaItem(nil), m.media...)
+}
+
+func (m *MessageRequest) decodeParams(p url.Values) error {
+	m.media = nil
+	for i := 0; i < m.NumMedia; i++ {
+		n := strconv.Itoa(i)
+		if u := p.Get("MediaUrl" + n); u != "" {
+			m.media = append(m.media, MediaItem{URL: u, ContentType: p.Get("MediaContentType" + n)})
+		}
+	}
+	return nil
+}
+
+// WhatsApp holds the parameters Twilio adds to incoming WhatsApp messages.
+type WhatsApp struct {
+	ProfileName                  string `twilio:"ProfileName"`
+	WaID                         string `twilio:"WaId"`
+	MessageType                  string `twilio:"MessageType"` // text, image, button, interactive, location and so on
+	ButtonText                   string `twilio:"ButtonText"`
+	ButtonPayload                string `twilio:"ButtonPayload"`
+	OriginalRepliedMessageSid    string `twilio:"OriginalRepliedMessageSid"`
+	OriginalRepliedMessageSender string `twilio:"OriginalRepliedMessageSender"`
+	Forwarded                    bool   `twilio:"Forwarded"`
+	FrequentlyForwarded          bool   `twilio:"FrequentlyForwarded"`
+}
+
+// A Channel is the messaging channel a message was sent over.
+type Channel string
+
+const (
+	ChannelSMS       Channel = "sms" // including MMS
+	ChannelWhatsApp  Channel = "whatsapp"
+	ChannelMessenger Channel = "messenger"
+	ChannelRCS       Channel = "rcs"
+)
+
+// Channel returns the channel of the message, from the prefix of its From
+// address, such as whatsapp: for WhatsApp.
+//
+// Example usage:
+//
+//	switch msg.Channel() {
+//	case webhook.ChannelWhatsApp:
+//		greet(msg.ProfileName)
+//	case webhook.ChannelSMS:
+//		...
+//	}
+func (m *MessageRequest) Channel() Channel {
+	return channelOf(m.From)
+}
+
+func channelOf(from PhoneNumber) Channel {
+	if channel, _, ok := strings.Cut(string(from), ":"); ok {
+		return Channel(channel)
+	}
+	return ChannelSMS
+}
+
+// DecodeMessage decodes the parameters of an incoming message webhook
+// request that has been validated by a twilio.Validator.
+func DecodeMessage(r *http.Request) (*MessageRequest, error) {
+	return Decode[*MessageRequest](r)
+}
+
+// A MessageStatusCallback holds the parameters Twilio sends to a message's
+// StatusCallback as its delivery status changes. ErrorCode is set for
+// messages that failed or were undelivered.
+type MessageStatusCallback struct {
+	MessageSid          string         `twilio:"MessageSid"`
+	SmsSid              string         `twilio:"SmsSid"`
+	AccountSid          string         `twilio:"AccountSid"`
+	MessagingServiceSid string         `twilio:"MessagingServiceSid"`
+	From                PhoneNumber    `twilio:"From"`
+	To                  PhoneNumber    `twilio:"To"`
+	MessageStatus       MessageStatus  `twilio:"MessageStatus"`
+	SmsStatus           MessageStatus  `twilio:"SmsStatus"`
+	ErrorCode           errorcode.Code `twilio:"ErrorCode"`
+	ErrorMessage        string         `twilio:"ErrorMessage"`
+	APIVersion          string         `twilio:"ApiVersion"`
+
+	// DlrDoneDate is when the carrier reported the message delivered or
+	// undelivered, to the minute. It is only sent for some carriers.
+	DlrDoneDate time.Time `twilio:"RawDlrDoneDate,layout=0601021504"`
+}
+
+// Channel returns the channel of the message, from the prefix of its To
+// address. See MessageRequest.Channel.
+func (m *MessageStatusCallback) Channel() Channel {
+	return channelOf(m.To)
+}
+
+// DecodeMessageStatus decodes the parameters of a message status callback
+// request that has been validated by a twilio.Validator.
+func DecodeMessageStatus(r *http.Request) (*MessageStatusCallback, error) {
+	return Decode[*MessageStatusCallback](r)
+}
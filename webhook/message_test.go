@@ -0,0 +1,165 @@
+package webhook_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeMessage(t *testing.T) {
+	r := validated(t, url.Values{
+		"MessageSid":          {"SM123"},
+		"SmsSid":              {"SM123"},
+		"SmsMessageSid":       {"SM123"},
+		"AccountSid":          {"AC456"},
+		"MessagingServiceSid": {"MG789"},
+		"From":                {"+14155550199"},
+		"To":                  {"+14155550100"},
+		"Body":                {"Hello & goodbye"},
+		"NumMedia":            {"2"},
+		"NumSegments":         {"1"},
+		"SmsStatus":           {"received"},
+		"ApiVersion":          {"2010-04-01"},
+	})
+	got, err := webhook.DecodeMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.MessageRequest{
+		MessageSid:          "SM123",
+		SmsSid:              "SM123",
+		SmsMessageSid:       "SM123",
+		AccountSid:          "AC456",
+		MessagingServiceSid: "MG789",
+		From:                "+14155550199",
+		To:                  "+14155550100",
+		Body:                "Hello & goodbye",
+		NumMedia:            2,
+		NumSegments:         1,
+		SmsStatus:           webhook.MessageReceived,
+		APIVersion:          "2010-04-01",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+
+	r = validated(t, url.Values{"MessageSid": {"SM123"}, "NumMedia": {"two"}})
+	if _, err := webhook.DecodeMessage(r); err == nil || !strings.Contains(err.Error(), "webhook: NumMedia:") {
+		t.Errorf("got %v, want an error about NumMedia", err)
+	}
+}
+
+func TestDecodeMessageStatus(t *testing.T) {
+	r := validated(t, url.Values{
+		"MessageSid":          {"SM123"},
+		"AccountSid":          {"AC456"},
+		"MessagingServiceSid": {"MG789"},
+		"From":                {"+14155550100"},
+		"To":                  {"+14155550199"},
+		"MessageStatus":       {"undelivered"},
+		"SmsStatus":           {"undelivered"},
+		"ErrorCode":           {"30003"},
+		"RawDlrDoneDate":      {"2311071950"},
+	})
+	got, err := webhook.DecodeMessageStatus(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.MessageStatusCallback{
+		MessageSid:          "SM123",
+		AccountSid:          "AC456",
+		MessagingServiceSid: "MG789",
+		From:                "+14155550100",
+		To:                  "+14155550199",
+		MessageStatus:       webhook.MessageUndelivered,
+		SmsStatus:           webhook.MessageUndelivered,
+		ErrorCode:           30003,
+		DlrDoneDate:         time.Date(2023, 11, 7, 19, 50, 0, 0, time.UTC),
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+	if name := got.ErrorCode.Name(); name != "Unreachable destination handset" {
+		t.Errorf("ErrorCode.Name() = %q", name)
+	}
+}
+
+func TestDecodeWhatsApp(t *testing.T) {
+	r := validated(t, url.Values{
+		"MessageSid":                {"SM123"},
+		"From":                      {"whatsapp:+5511987654321"},
+		"To":                        {"whatsapp:+14155550100"},
+		"Body":                      {"Yes"},
+		"ProfileName":               {"Ada"},
+		"WaId":                      {"5511987654321"},
+		"MessageType":               {"button"},
+		"ButtonText":                {"Yes"},
+		"ButtonPayload":             {"confirm"},
+		"OriginalRepliedMessageSid": {"SM000"},
+		"Forwarded":                 {"true"},
+	})
+	got, err := webhook.DecodeMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.WhatsApp{
+		ProfileName:               "Ada",
+		WaID:                      "5511987654321",
+		MessageType:               "button",
+		ButtonText:                "Yes",
+		ButtonPayload:             "confirm",
+		OriginalRepliedMessageSid: "SM000",
+		Forwarded:                 true,
+	}
+	if got.WhatsApp != want {
+		t.Errorf("got %+v\nwant %+v", got.WhatsApp, want)
+	}
+	if got.Channel() != webhook.ChannelWhatsApp {
+		t.Errorf("Channel() = %q, want whatsapp", got.Channel())
+	}
+
+	sms := webhook.MessageRequest{From: "+14155550199"}
+	if sms.Channel() != webhook.ChannelSMS {
+		t.Errorf("Channel() = %q, want sms", sms.Channel())
+	}
+	status := webhook.MessageStatusCallback{To: "messenger:123456"}
+	if status.Channel() != webhook.ChannelMessenger {
+		t.Errorf("Channel() = %q, want messenger", status.Channel())
+	}
+}
+
+func TestMessageMedia(t *testing.T) {
+	r := validated(t, url.Values{
+		"MessageSid":        {"MM123"},
+		"NumMedia":          {"2"},
+		"MediaUrl0":         {"https://api.twilio.com/media/ME0"},
+		"MediaContentType0": {"image/jpeg"},
+		"MediaUrl1":         {"https://api.twilio.com/media/ME1"},
+		"MediaContentType1": {"application/pdf"},
+		"MediaUrl2":         {"https://api.twilio.com/media/ignored"},
+	})
+	msg, err := webhook.DecodeMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []webhook.MediaItem{
+		{URL: "https://api.twilio.com/media/ME0", ContentType: "image/jpeg"},
+		{URL: "https://api.twilio.com/media/ME1", ContentType: "application/pdf"},
+	}
+	if got := msg.Media(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Media() = %+v, want %+v", got, want)
+	}
+	msg.Media()[0].URL = "changed"
+	if msg.Media()[0].URL == "changed" {
+		t.Error("Media() should return a copy")
+	}
+
+	var empty webhook.MessageRequest
+	if got := empty.Media(); len(got) != 0 {
+		t.Errorf("Media() of a message without media = %+v", got)
+	}
+}
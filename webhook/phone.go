@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A PhoneNumber is the address of a party to a call or message, as Twilio
+// sends it in parameters such as From and To. It is usually a phone number
+// in E.164 format, such as +14155550100, but can also be a number on
+// another channel, such as whatsapp:+14155550100, or not a number at all:
+// client:alice for a Voice SDK client, a SIP URI, or Anonymous for a
+// withheld caller ID. PhoneNumber keeps whatever Twilio sent; use Valid to
+// tell whether it is a number.
+type PhoneNumber string
+
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// ParsePhoneNumber returns s as a PhoneNumber, or an error if it isn't a
+// phone number in E.164 format.
+func ParsePhoneNumber(s string) (PhoneNumber, error) {
+	if !e164.MatchString(s) {
+		return "", fmt.Errorf("webhook: %q is not an E.164 phone number", s)
+	}
+	return PhoneNumber(s), nil
+}
+
+// E164 returns the number in E.164 format, without any channel prefix such
+// as whatsapp:, or the empty string if p isn't a phone number.
+func (p PhoneNumber) E164() string {
+	s := string(p)
+	if channel, number, ok := strings.Cut(s, ":"); ok && !strings.ContainsAny(channel, "+@") {
+		s = number
+	}
+	if !e164.MatchString(s) {
+		return ""
+	}
+	return s
+}
+
+// Valid reports whether p is a phone number in E.164 format, possibly on a
+// channel such as WhatsApp.
+func (p PhoneNumber) Valid() bool {
+	return p.E164() != ""
+}
+
+// CountryCode returns the number's country calling code, such as 1 for the
+// United States and Canada or 44 for the United Kingdom, or the empty
+// string if p isn't a phone number.
+func (p PhoneNumber) CountryCode() string {
+	code, _ := p.split()
+	return code
+}
+
+// National returns the number without its country calling code, or the
+// empty string if p isn't a phone number.
+func (p PhoneNumber) National() string {
+	_, national := p.split()
+	return national
+}
+
+// twoDigitCodes are the two-digit country calling codes. Calling codes
+// are prefix-free, so the others are 1 and 7 and three-digit codes.
+var twoDigitCodes = map[string]bool{
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true,
+	"34": true, "36": true, "39": true, "40": true, "41": true, "43": true,
+	"44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "52": true, "53": true, "54": true, "55": true, "56": true,
+	"57": true, "58": true, "60": true, "61": true, "62": true, "63": true,
+	"64": true, "65": true, "66": true, "81": true, "82": true, "84": true,
+	"86": true, "90": true, "91": true, "92": true, "93": true, "94": true,
+	"95": true, "98": true,
+}
+
+func (p PhoneNumber) split() (code, national string) {
+	digits := strings.TrimPrefix(p.E164(), "+")
+	n := 3
+	switch {
+	case digits == "":
+		return "", ""
+	case digits[0] == '1' || digits[0] == '7':
+		n = 1
+	case twoDigitCodes[digits[:2]]:
+		n = 2
+	}
+	if n >= len(digits) {
+		return "", ""
+	}
+	return digits[:n], digits[n:]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p PhoneNumber) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any
+// address, since Twilio sends addresses that aren't phone numbers in the
+// same parameters; use ParsePhoneNumber to insist on one.
+func (p *PhoneNumber) UnmarshalText(text []byte) error {
+	*p = PhoneNumber(text)
+	return nil
+}
@@ -0,0 +1,70 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestPhoneNumber(t *testing.T) {
+	tests := []struct {
+		p                    webhook.PhoneNumber
+		e164, code, national string
+	}{
+		{"+14155550100", "+14155550100", "1", "4155550100"},
+		{"+442079460000", "+442079460000", "44", "2079460000"},
+		{"+79123456789", "+79123456789", "7", "9123456789"},
+		{"+353861234567", "+353861234567", "353", "861234567"},
+		{"whatsapp:+5511987654321", "+5511987654321", "55", "11987654321"},
+		{"client:alice", "", "", ""},
+		{"sip:alice@example.com", "", "", ""},
+		{"Anonymous", "", "", ""},
+		{"4155550100", "", "", ""},
+		{"+0123", "", "", ""},
+	}
+	for _, test := range tests {
+		if got := test.p.E164(); got != test.e164 {
+			t.Errorf("%s.E164() = %q, want %q", test.p, got, test.e164)
+		}
+		if got := test.p.Valid(); got != (test.e164 != "") {
+			t.Errorf("%s.Valid() = %v", test.p, got)
+		}
+		if got := test.p.CountryCode(); got != test.code {
+			t.Errorf("%s.CountryCode() = %q, want %q", test.p, got, test.code)
+		}
+		if got := test.p.National(); got != test.national {
+			t.Errorf("%s.National() = %q, want %q", test.p, got, test.national)
+		}
+	}
+}
+
+func TestParsePhoneNumber(t *testing.T) {
+	if p, err := webhook.ParsePhoneNumber("+14155550100"); err != nil || p != "+14155550100" {
+		t.Errorf("got %q, %v", p, err)
+	}
+	for _, s := range []string{"", "4155550100", "+1415555010012345", "whatsapp:+14155550100", "+1 415 555 0100"} {
+		if _, err := webhook.ParsePhoneNumber(s); err == nil {
+			t.Errorf("ParsePhoneNumber(%q) should fail", s)
+		}
+	}
+}
+
+func TestPhoneNumberEncoding(t *testing.T) {
+	call, err := webhook.DecodeVoice(validated(t, url.Values{"From": {"client:alice"}, "To": {"+14155550100"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.From != "client:alice" || call.From.Valid() || !call.To.Valid() {
+		t.Errorf("got From %q and To %q", call.From, call.To)
+	}
+
+	b, err := json.Marshal(map[webhook.PhoneNumber]webhook.PhoneNumber{call.To: call.From})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"+14155550100":"client:alice"}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+)
+
+// A QueueWaitRequest holds the parameters Twilio sends to an Enqueue's
+// WaitURL while the caller waits in the queue. The TwiML it returns is
+// played to the caller, so it can announce their position or offer them a
+// way out with Leave.
+type QueueWaitRequest struct {
+	VoiceRequest
+	QueueSid         string        `twilio:"QueueSid"`
+	QueuePosition    int           `twilio:"QueuePosition"`
+	QueueTime        time.Duration `twilio:"QueueTime"`
+	AvgQueueTime     time.Duration `twilio:"AvgQueueTime"`
+	CurrentQueueSize int           `twilio:"CurrentQueueSize"`
+	MaxQueueSize     int           `twilio:"MaxQueueSize"`
+}
+
+// DecodeQueueWait decodes the parameters of an Enqueue wait URL request
+// that has been validated by a twilio.Validator.
+func DecodeQueueWait(r *http.Request) (*QueueWaitRequest, error) {
+	return Decode[*QueueWaitRequest](r)
+}
+
+// A QueueResult is how a caller's time in a queue ended.
+type QueueResult string
+
+const (
+	QueueBridged           QueueResult = "bridged"
+	QueueBridgingInProcess QueueResult = "bridging-in-process"
+	QueueFull              QueueResult = "queue-full"
+	QueueRedirected        QueueResult = "redirected"
+	QueueHangup            QueueResult = "hangup"
+	QueueLeave             QueueResult = "leave"
+	QueueError             QueueResult = "error"
+	QueueSystemError       QueueResult = "system-error"
+)
+
+// An EnqueueCallback holds the parameters Twilio sends to an Enqueue's
+// Action when the caller leaves the queue. A QueueResult of QueueFull is
+// the cue for overflow handling, such as taking a voicemail.
+type EnqueueCallback struct {
+	VoiceRequest
+	QueueResult QueueResult   `twilio:"QueueResult"`
+	QueueSid    string        `twilio:"QueueSid"`
+	QueueTime   time.Duration `twilio:"QueueTime"`
+}
+
+// DecodeEnqueue decodes the parameters of an Enqueue action request that
+// has been validated by a twilio.Validator.
+func DecodeEnqueue(r *http.Request) (*EnqueueCallback, error) {
+	return Decode[*EnqueueCallback](r)
+}
+
+// A DequeueRequest holds the parameters Twilio sends to a Dial Queue
+// noun's URL, on behalf of the caller being taken off the queue, just
+// before they are connected to DequeueingCallSid.
+type DequeueRequest struct {
+	VoiceRequest
+	QueueSid          string        `twilio:"QueueSid"`
+	QueueTime         time.Duration `twilio:"QueueTime"`
+	DequeueingCallSid string        `twilio:"DequeueingCallSid"`
+}
+
+// DecodeDequeue decodes the parameters of a Dial Queue URL request that
+// has been validated by a twilio.Validator.
+func DecodeDequeue(r *http.Request) (*DequeueRequest, error) {
+	return Decode[*DequeueRequest](r)
+}
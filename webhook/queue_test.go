@@ -0,0 +1,71 @@
+package webhook_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeQueueWait(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":          {"CA123"},
+		"QueueSid":         {"QU456"},
+		"QueuePosition":    {"3"},
+		"QueueTime":        {"95"},
+		"AvgQueueTime":     {"120"},
+		"CurrentQueueSize": {"7"},
+		"MaxQueueSize":     {"100"},
+	})
+	got, err := webhook.DecodeQueueWait(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.QueueWaitRequest{
+		VoiceRequest:     webhook.VoiceRequest{CallSid: "CA123"},
+		QueueSid:         "QU456",
+		QueuePosition:    3,
+		QueueTime:        95 * time.Second,
+		AvgQueueTime:     2 * time.Minute,
+		CurrentQueueSize: 7,
+		MaxQueueSize:     100,
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeEnqueue(t *testing.T) {
+	r := validated(t, url.Values{"CallSid": {"CA123"}, "QueueResult": {"queue-full"}, "QueueSid": {"QU456"}})
+	got, err := webhook.DecodeEnqueue(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.EnqueueCallback{
+		VoiceRequest: webhook.VoiceRequest{CallSid: "CA123"},
+		QueueResult:  webhook.QueueFull,
+		QueueSid:     "QU456",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeDequeue(t *testing.T) {
+	r := validated(t, url.Values{"CallSid": {"CA123"}, "QueueSid": {"QU456"}, "QueueTime": {"30"}, "DequeueingCallSid": {"CA789"}})
+	got, err := webhook.DecodeDequeue(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.DequeueRequest{
+		VoiceRequest:      webhook.VoiceRequest{CallSid: "CA123"},
+		QueueSid:          "QU456",
+		QueueTime:         30 * time.Second,
+		DequeueingCallSid: "CA789",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/errorcode"
+)
+
+// A RecordingStatusCallback holds the parameters Twilio sends to a
+// recording's status callback, such as Record's RecordingStatusCallback,
+// as the recording progresses.
+type RecordingStatusCallback struct {
+	AccountSid         string         `twilio:"AccountSid"`
+	CallSid            string         `twilio:"CallSid"`
+	ConferenceSid      string         `twilio:"ConferenceSid"`
+	RecordingSid       string         `twilio:"RecordingSid"`
+	RecordingURL       string         `twilio:"RecordingUrl"`
+	RecordingStatus    string         `twilio:"RecordingStatus"` // in-progress, completed, absent or failed
+	RecordingDuration  time.Duration  `twilio:"RecordingDuration"`
+	RecordingChannels  int            `twilio:"RecordingChannels"`
+	RecordingStartTime time.Time      `twilio:"RecordingStartTime"`
+	RecordingSource    string         `twilio:"RecordingSource"`
+	RecordingTrack     string         `twilio:"RecordingTrack"`
+	ErrorCode          errorcode.Code `twilio:"ErrorCode"`
+}
+
+// DecodeRecordingStatus decodes the parameters of a recording status
+// callback request that has been validated by a twilio.Validator.
+func DecodeRecordingStatus(r *http.Request) (*RecordingStatusCallback, error) {
+	return Decode[*RecordingStatusCallback](r)
+}
+
+// A TranscriptionCallback holds the parameters Twilio sends to Record's
+// TranscribeCallback once a recording has been transcribed.
+type TranscriptionCallback struct {
+	AccountSid          string      `twilio:"AccountSid"`
+	CallSid             string      `twilio:"CallSid"`
+	From                PhoneNumber `twilio:"From"`
+	To                  PhoneNumber `twilio:"To"`
+	TranscriptionSid    string      `twilio:"TranscriptionSid"`
+	TranscriptionText   string      `twilio:"TranscriptionText"`
+	TranscriptionStatus string      `twilio:"TranscriptionStatus"` // completed or failed
+	TranscriptionURL    string      `twilio:"TranscriptionUrl"`
+	RecordingSid        string      `twilio:"RecordingSid"`
+	RecordingURL        string      `twilio:"RecordingUrl"`
+}
+
+// DecodeTranscription decodes the parameters of a transcription callback
+// request that has been validated by a twilio.Validator.
+func DecodeTranscription(r *http.Request) (*TranscriptionCallback, error) {
+	return Decode[*TranscriptionCallback](r)
+}
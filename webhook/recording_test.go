@@ -0,0 +1,73 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeRecordingStatus(t *testing.T) {
+	r := validated(t, url.Values{
+		"AccountSid":         {"AC456"},
+		"CallSid":            {"CA123"},
+		"RecordingSid":       {"RE789"},
+		"RecordingUrl":       {"https://api.twilio.com/2010-04-01/Accounts/AC456/Recordings/RE789"},
+		"RecordingStatus":    {"completed"},
+		"RecordingDuration":  {"15"},
+		"RecordingChannels":  {"2"},
+		"RecordingStartTime": {"Tue, 07 Nov 2023 19:50:55 +0000"},
+		"RecordingSource":    {"RecordVerb"},
+	})
+	got, err := webhook.DecodeRecordingStatus(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.RecordingStatusCallback{
+		AccountSid:         "AC456",
+		CallSid:            "CA123",
+		RecordingSid:       "RE789",
+		RecordingURL:       "https://api.twilio.com/2010-04-01/Accounts/AC456/Recordings/RE789",
+		RecordingStatus:    "completed",
+		RecordingDuration:  15 * time.Second,
+		RecordingChannels:  2,
+		RecordingStartTime: time.Date(2023, 11, 7, 19, 50, 55, 0, time.UTC),
+		RecordingSource:    "RecordVerb",
+	}
+	if !got.RecordingStartTime.Equal(want.RecordingStartTime) {
+		t.Errorf("RecordingStartTime = %v, want %v", got.RecordingStartTime, want.RecordingStartTime)
+	}
+	got.RecordingStartTime = want.RecordingStartTime
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeTranscription(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":             {"CA123"},
+		"From":                {"+14155550199"},
+		"TranscriptionSid":    {"TR111"},
+		"TranscriptionText":   {"Hi, it's Ada. Call me back."},
+		"TranscriptionStatus": {"completed"},
+		"TranscriptionUrl":    {"https://api.twilio.com/2010-04-01/Accounts/AC456/Transcriptions/TR111"},
+		"RecordingSid":        {"RE789"},
+	})
+	got, err := webhook.DecodeTranscription(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.TranscriptionCallback{
+		CallSid:             "CA123",
+		From:                "+14155550199",
+		TranscriptionSid:    "TR111",
+		TranscriptionText:   "Hi, it's Ada. Call me back.",
+		TranscriptionStatus: "completed",
+		TranscriptionURL:    "https://api.twilio.com/2010-04-01/Accounts/AC456/Transcriptions/TR111",
+		RecordingSid:        "RE789",
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import "strings"
+
+// A CallStatus is the state of a call, as sent in CallStatus and
+// DialCallStatus parameters.
+type CallStatus string
+
+const (
+	CallQueued     CallStatus = "queued"
+	CallInitiated  CallStatus = "initiated"
+	CallRinging    CallStatus = "ringing"
+	CallInProgress CallStatus = "in-progress"
+	CallCompleted  CallStatus = "completed"
+	CallBusy       CallStatus = "busy"
+	CallNoAnswer   CallStatus = "no-answer"
+	CallCanceled   CallStatus = "canceled"
+	CallFailed     CallStatus = "failed"
+)
+
+// IsTerminal reports whether the call has ended, so its status won't
+// change again.
+func (s CallStatus) IsTerminal() bool {
+	switch s {
+	case CallCompleted, CallBusy, CallNoAnswer, CallCanceled, CallFailed:
+		return true
+	}
+	return false
+}
+
+// IsFailed reports whether the call ended without being connected: it
+// was busy, wasn't answered, was canceled or couldn't be placed.
+func (s CallStatus) IsFailed() bool { return s.IsTerminal() && s != CallCompleted }
+
+// A MessageStatus is the state of a message, as sent in MessageStatus
+// and SmsStatus parameters.
+type MessageStatus string
+
+const (
+	MessageAccepted           MessageStatus = "accepted"
+	MessageScheduled          MessageStatus = "scheduled"
+	MessageQueued             MessageStatus = "queued"
+	MessageSending            MessageStatus = "sending"
+	MessageSent               MessageStatus = "sent"
+	MessageDelivered          MessageStatus = "delivered"
+	MessageUndelivered        MessageStatus = "undelivered"
+	MessageFailed             MessageStatus = "failed"
+	MessagePartiallyDelivered MessageStatus = "partially_delivered"
+	MessageRead               MessageStatus = "read"
+	MessageCanceled           MessageStatus = "canceled"
+	MessageReceiving          MessageStatus = "receiving"
+	MessageReceived           MessageStatus = "received"
+)
+
+// IsTerminal reports whether the message has reached a final state. A
+// delivered message can still be reported read on channels with read
+// receipts, such as WhatsApp.
+func (s MessageStatus) IsTerminal() bool {
+	switch s {
+	case MessageDelivered, MessageUndelivered, MessageFailed, MessagePartiallyDelivered,
+		MessageRead, MessageCanceled, MessageReceived:
+		return true
+	}
+	return false
+}
+
+// IsFailed reports whether the message couldn't be sent or delivered. The
+// callback's ErrorCode says why.
+func (s MessageStatus) IsFailed() bool { return s == MessageUndelivered || s == MessageFailed }
+
+// A Direction is which way a call or message went, and what started it.
+type Direction string
+
+const (
+	DirectionInbound       Direction = "inbound"
+	DirectionOutboundAPI   Direction = "outbound-api"   // placed or sent with the REST API
+	DirectionOutboundDial  Direction = "outbound-dial"  // a call placed by Dial
+	DirectionOutboundCall  Direction = "outbound-call"  // a message sent by Message during a call
+	DirectionOutboundReply Direction = "outbound-reply" // a message sent in reply to one received
+)
+
+// IsInbound reports whether the call or message came in to Twilio.
+func (d Direction) IsInbound() bool { return d == DirectionInbound }
+
+// IsOutbound reports whether the call or message went out from Twilio.
+func (d Direction) IsOutbound() bool { return strings.HasPrefix(string(d), "outbound-") }
@@ -0,0 +1,66 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestCallStatus(t *testing.T) {
+	tests := []struct {
+		s                webhook.CallStatus
+		terminal, failed bool
+	}{
+		{webhook.CallQueued, false, false},
+		{webhook.CallRinging, false, false},
+		{webhook.CallInProgress, false, false},
+		{webhook.CallCompleted, true, false},
+		{webhook.CallBusy, true, true},
+		{webhook.CallNoAnswer, true, true},
+		{webhook.CallCanceled, true, true},
+		{webhook.CallFailed, true, true},
+		{"", false, false},
+	}
+	for _, test := range tests {
+		if test.s.IsTerminal() != test.terminal || test.s.IsFailed() != test.failed {
+			t.Errorf("%q: got IsTerminal %v and IsFailed %v", test.s, test.s.IsTerminal(), test.s.IsFailed())
+		}
+	}
+}
+
+func TestMessageStatus(t *testing.T) {
+	tests := []struct {
+		s                webhook.MessageStatus
+		terminal, failed bool
+	}{
+		{webhook.MessageQueued, false, false},
+		{webhook.MessageSent, false, false},
+		{webhook.MessageReceiving, false, false},
+		{webhook.MessageDelivered, true, false},
+		{webhook.MessageRead, true, false},
+		{webhook.MessageReceived, true, false},
+		{webhook.MessageUndelivered, true, true},
+		{webhook.MessageFailed, true, true},
+	}
+	for _, test := range tests {
+		if test.s.IsTerminal() != test.terminal || test.s.IsFailed() != test.failed {
+			t.Errorf("%q: got IsTerminal %v and IsFailed %v", test.s, test.s.IsTerminal(), test.s.IsFailed())
+		}
+	}
+}
+
+func TestDirection(t *testing.T) {
+	for d, inbound := range map[webhook.Direction]bool{
+		webhook.DirectionInbound:       true,
+		webhook.DirectionOutboundAPI:   false,
+		webhook.DirectionOutboundDial:  false,
+		webhook.DirectionOutboundReply: false,
+	} {
+		if d.IsInbound() != inbound || d.IsOutbound() == inbound {
+			t.Errorf("%q: got IsInbound %v and IsOutbound %v", d, d.IsInbound(), d.IsOutbound())
+		}
+	}
+	if d := webhook.Direction(""); d.IsInbound() || d.IsOutbound() {
+		t.Error("empty direction is inbound or outbound")
+	}
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/jeremyschlatter/twilio-middleware/twiml"
+)
+
+// A StirVerstat is the result of Twilio's STIR/SHAKEN verification of an
+// incoming call's caller ID, which Twilio sends in the StirVerstat
+// parameter. It is empty for calls that weren't signed, such as calls from
+// carriers outside the US and Canada.
+type StirVerstat string
+
+const (
+	VerstatPassedA      StirVerstat = "TN-Validation-Passed-A"
+	VerstatPassedB      StirVerstat = "TN-Validation-Passed-B"
+	VerstatPassedC      StirVerstat = "TN-Validation-Passed-C"
+	VerstatFailedA      StirVerstat = "TN-Validation-Failed-A"
+	VerstatFailedB      StirVerstat = "TN-Validation-Failed-B"
+	VerstatFailedC      StirVerstat = "TN-Validation-Failed-C"
+	VerstatFailed       StirVerstat = "TN-Validation-Failed"
+	VerstatNoValidation StirVerstat = "No-TN-Validation"
+)
+
+// An Attestation is how much the originating carrier vouches for a call's
+// caller ID: AttestationA, full, means it knows the caller and that they
+// may use the number; AttestationB, partial, that it knows the caller but
+// not their right to the number; and AttestationC, gateway, only where the
+// call entered its network.
+type Attestation string
+
+const (
+	AttestationA Attestation = "A"
+	AttestationB Attestation = "B"
+	AttestationC Attestation = "C"
+)
+
+// rank orders attestations from weakest to strongest, with 0 for none.
+func (a Attestation) rank() int {
+	switch a {
+	case AttestationA:
+		return 3
+	case AttestationB:
+		return 2
+	case AttestationC:
+		return 1
+	}
+	return 0
+}
+
+// Passed reports whether the call's signature was verified.
+func (v StirVerstat) Passed() bool { return strings.HasPrefix(string(v), "TN-Validation-Passed") }
+
+// Failed reports whether the call was signed but its signature couldn't
+// be verified.
+func (v StirVerstat) Failed() bool { return strings.HasPrefix(string(v), "TN-Validation-Failed") }
+
+// Attestation returns the attestation the call was signed with, whether
+// or not the signature was verified, or "" if it isn't known.
+func (v StirVerstat) Attestation() Attestation {
+	s := string(v)
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		if a := Attestation(s[i+1:]); a.rank() > 0 {
+			return a
+		}
+	}
+	return ""
+}
+
+// A StirPolicy decides which incoming calls to accept by their
+// STIR/SHAKEN verification. Its Middleware enforces it in front of a
+// voice webhook handler.
+type StirPolicy struct {
+	// MinAttestation is the weakest attestation accepted, of calls whose
+	// signature was verified. Empty accepts any verified call.
+	MinAttestation Attestation
+
+	// AllowUnsigned accepts calls that weren't signed, or that Twilio
+	// didn't verify, which includes most international calls.
+	AllowUnsigned bool
+
+	// Flag, if set, is called for calls the policy doesn't accept, which
+	// are then passed on to the handler instead of being rejected.
+	Flag func(r *http.Request, call *VoiceRequest)
+}
+
+// Allows reports whether p accepts a call with verification result v.
+func (p StirPolicy) Allows(v StirVerstat) bool {
+	switch {
+	case v.Passed():
+		return v.Attestation().rank() >= p.MinAttestation.rank()
+	case v.Failed():
+		return false
+	}
+	return p.AllowUnsigned
+}
+
+// Middleware returns a handler that applies p to incoming calls before
+// passing them on to next. Calls it doesn't accept are declined with a
+// Reject response, so they aren't answered or billed, unless p.Flag is
+// set. Requests for outgoing calls, and requests that can't be decoded,
+// are passed on unchanged.
+//
+// The handler must run behind a twilio.Validator.
+//
+// Example usage:
+//
+//	policy := webhook.StirPolicy{MinAttestation: webhook.AttestationB}
+//	http.Handle("/voice", v.Middleware(policy.Middleware(voiceHandler)))
+func (p StirPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call, err := DecodeVoice(r)
+		if err != nil || !call.Direction.IsInbound() || p.Allows(call.StirVerstat) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if p.Flag != nil {
+			p.Flag(r, call)
+			next.ServeHTTP(w, r)
+			return
+		}
+		twiml.Write(w, new(twiml.Response).Reject())
+	})
+}
@@ -0,0 +1,92 @@
+package webhook_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestStirVerstat(t *testing.T) {
+	tests := []struct {
+		v              webhook.StirVerstat
+		passed, failed bool
+		a              webhook.Attestation
+	}{
+		{webhook.VerstatPassedA, true, false, webhook.AttestationA},
+		{webhook.VerstatPassedC, true, false, webhook.AttestationC},
+		{webhook.VerstatFailedB, false, true, webhook.AttestationB},
+		{webhook.VerstatFailed, false, true, ""},
+		{webhook.VerstatNoValidation, false, false, ""},
+		{"", false, false, ""},
+	}
+	for _, test := range tests {
+		if test.v.Passed() != test.passed || test.v.Failed() != test.failed || test.v.Attestation() != test.a {
+			t.Errorf("%q: got %v, %v, %q", test.v, test.v.Passed(), test.v.Failed(), test.v.Attestation())
+		}
+	}
+}
+
+func TestDecodeVoiceStir(t *testing.T) {
+	call, err := webhook.DecodeVoice(validated(t, url.Values{
+		"StirVerstat":       {"TN-Validation-Passed-B"},
+		"StirPassportToken": {"eyJhbGciOiJFUzI1NiJ9.e30.sig"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.StirVerstat != webhook.VerstatPassedB || call.StirPassportToken != "eyJhbGciOiJFUzI1NiJ9.e30.sig" {
+		t.Errorf("got %q and %q", call.StirVerstat, call.StirPassportToken)
+	}
+}
+
+func TestStirPolicy(t *testing.T) {
+	p := webhook.StirPolicy{MinAttestation: webhook.AttestationB}
+	for v, want := range map[webhook.StirVerstat]bool{
+		webhook.VerstatPassedA:      true,
+		webhook.VerstatPassedB:      true,
+		webhook.VerstatPassedC:      false,
+		webhook.VerstatFailedA:      false,
+		webhook.VerstatNoValidation: false,
+		"":                          false,
+	} {
+		if got := p.Allows(v); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", v, got, want)
+		}
+	}
+	p = webhook.StirPolicy{AllowUnsigned: true}
+	if !p.Allows("") || !p.Allows(webhook.VerstatPassedC) || p.Allows(webhook.VerstatFailed) {
+		t.Error("AllowUnsigned policy: wrong results")
+	}
+}
+
+func TestStirPolicyMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("answered"))
+	})
+	serve := func(p webhook.StirPolicy, params url.Values) string {
+		w := httptest.NewRecorder()
+		p.Middleware(next).ServeHTTP(w, validated(t, params))
+		return w.Body.String()
+	}
+	const rejected = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<Response><Reject></Reject></Response>`
+	strict := webhook.StirPolicy{MinAttestation: webhook.AttestationA}
+
+	if got := serve(strict, url.Values{"Direction": {"inbound"}, "StirVerstat": {"TN-Validation-Passed-A"}}); got != "answered" {
+		t.Errorf("attested call: got %q", got)
+	}
+	if got := serve(strict, url.Values{"Direction": {"inbound"}, "StirVerstat": {"TN-Validation-Passed-C"}}); got != rejected {
+		t.Errorf("gateway call: got %q, want %q", got, rejected)
+	}
+	if got := serve(strict, url.Values{"Direction": {"outbound-api"}}); got != "answered" {
+		t.Errorf("outgoing call: got %q", got)
+	}
+
+	var flagged string
+	strict.Flag = func(r *http.Request, call *webhook.VoiceRequest) { flagged = call.CallSid }
+	if got := serve(strict, url.Values{"CallSid": {"CA123"}, "Direction": {"inbound"}}); got != "answered" || flagged != "CA123" {
+		t.Errorf("flagged call: got %q and flagged %q", got, flagged)
+	}
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+)
+
+// A StudioRequest holds the parameters of a request from a Studio Flow's
+// Make HTTP Request or Run Function widget. Studio only sends the
+// parameters configured on the widget, so these follow the names Twilio's
+// own templates use; set them up in the widget with Liquid, as in
+//
+//	FlowSid        {{flow.flow_sid}}
+//	ExecutionSid   {{flow.sid}}
+//	StepSid        {{widgets.my_widget.sid}}
+//	ChannelAddress {{contact.channel.address}}
+//
+// Embed StudioRequest in a struct of your own, and decode it with Decode,
+// to get the widget's other parameters too.
+//
+// Example usage:
+//
+//	type lookup struct {
+//		webhook.StudioRequest
+//		AccountNumber string `twilio:"AccountNumber"`
+//	}
+//
+//	req, err := webhook.Decode[lookup](r)
+type StudioRequest struct {
+	AccountSid     string      `twilio:"AccountSid"`
+	FlowSid        string      `twilio:"FlowSid"`
+	ExecutionSid   string      `twilio:"ExecutionSid"`
+	StepSid        string      `twilio:"StepSid"`
+	ChannelAddress PhoneNumber `twilio:"ChannelAddress"`
+}
+
+// DecodeStudio decodes the parameters of a Studio widget request that has
+// been validated by a twilio.Validator.
+func DecodeStudio(r *http.Request) (*StudioRequest, error) {
+	return Decode[*StudioRequest](r)
+}
+
+// A StudioExecutionStatus is the state of a Studio Flow execution.
+type StudioExecutionStatus string
+
+const (
+	StudioExecutionActive StudioExecutionStatus = "active"
+	StudioExecutionEnded  StudioExecutionStatus = "ended"
+)
+
+// A StudioFlowEvent holds the parameters of a Studio Flow execution
+// event, sent as an execution starts, moves from one widget to the next,
+// and ends. The step fields are set for step events. Context holds the
+// execution's context as JSON, including the variables its widgets have
+// set, for json.Unmarshal into a type of your own.
+type StudioFlowEvent struct {
+	AccountSid     string                `twilio:"AccountSid"`
+	FlowSid        string                `twilio:"FlowSid"`
+	FlowRevision   int                   `twilio:"FlowRevision"`
+	ExecutionSid   string                `twilio:"ExecutionSid"`
+	Status         StudioExecutionStatus `twilio:"Status"`
+	ChannelAddress PhoneNumber           `twilio:"ContactChannelAddress"`
+	Context        string                `twilio:"Context"`
+	DateCreated    time.Time             `twilio:"DateCreated"`
+	DateUpdated    time.Time             `twilio:"DateUpdated"`
+
+	StepSid          string `twilio:"StepSid"`
+	StepName         string `twilio:"StepName"`
+	TransitionedFrom string `twilio:"TransitionedFrom"`
+	TransitionedTo   string `twilio:"TransitionedTo"`
+}
+
+// DecodeStudioFlowEvent decodes the parameters of a Studio Flow event that
+// has been validated by a twilio.Validator.
+func DecodeStudioFlowEvent(r *http.Request) (*StudioFlowEvent, error) {
+	return Decode[*StudioFlowEvent](r)
+}
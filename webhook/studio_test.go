@@ -0,0 +1,66 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeStudio(t *testing.T) {
+	r := validated(t, url.Values{
+		"FlowSid":        {"FW123"},
+		"ExecutionSid":   {"FN456"},
+		"StepSid":        {"FT789"},
+		"ChannelAddress": {"+14155550100"},
+		"AccountNumber":  {"42"},
+	})
+	got, err := webhook.Decode[struct {
+		webhook.StudioRequest
+		AccountNumber string `twilio:"AccountNumber"`
+	}](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.StudioRequest{FlowSid: "FW123", ExecutionSid: "FN456", StepSid: "FT789", ChannelAddress: "+14155550100"}
+	if got.StudioRequest != want || got.AccountNumber != "42" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestDecodeStudioFlowEvent(t *testing.T) {
+	r := validated(t, url.Values{
+		"FlowSid":               {"FW123"},
+		"FlowRevision":          {"7"},
+		"ExecutionSid":          {"FN456"},
+		"Status":                {"ended"},
+		"ContactChannelAddress": {"+14155550100"},
+		"Context":               {`{"flow":{"variables":{"tier":"gold"}}}`},
+		"DateUpdated":           {"2024-05-01T12:00:00Z"},
+		"StepSid":               {"FT789"},
+		"StepName":              {"say_goodbye"},
+		"TransitionedFrom":      {"gather_input"},
+		"TransitionedTo":        {"Ended"},
+	})
+	got, err := webhook.DecodeStudioFlowEvent(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.StudioFlowEvent{
+		FlowSid:          "FW123",
+		FlowRevision:     7,
+		ExecutionSid:     "FN456",
+		Status:           webhook.StudioExecutionEnded,
+		ChannelAddress:   "+14155550100",
+		Context:          `{"flow":{"variables":{"tier":"gold"}}}`,
+		DateUpdated:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		StepSid:          "FT789",
+		StepName:         "say_goodbye",
+		TransitionedFrom: "gather_input",
+		TransitionedTo:   "Ended",
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
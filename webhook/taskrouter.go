@@ -0,0 +1,265 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// A TaskRouterEventType is the kind of event a TaskRouter event callback
+// reports.
+type TaskRouterEventType string
+
+const (
+	TaskCreated            TaskRouterEventType = "task.created"
+	TaskUpdated            TaskRouterEventType = "task.updated"
+	TaskCanceled           TaskRouterEventType = "task.canceled"
+	TaskWrapup             TaskRouterEventType = "task.wrapup"
+	TaskCompleted          TaskRouterEventType = "task.completed"
+	TaskDeleted            TaskRouterEventType = "task.deleted"
+	TaskSystemDeleted      TaskRouterEventType = "task.system-deleted"
+	TaskTransferInitiated  TaskRouterEventType = "task.transfer-initiated"
+	TaskTransferFailed     TaskRouterEventType = "task.transfer-failed"
+	TaskTransferCompleted  TaskRouterEventType = "task.transfer-completed"
+	ReservationCreated     TaskRouterEventType = "reservation.created"
+	ReservationAccepted    TaskRouterEventType = "reservation.accepted"
+	ReservationRejected    TaskRouterEventType = "reservation.rejected"
+	ReservationTimeout     TaskRouterEventType = "reservation.timeout"
+	ReservationCanceled    TaskRouterEventType = "reservation.canceled"
+	ReservationRescinded   TaskRouterEventType = "reservation.rescinded"
+	ReservationWrapup      TaskRouterEventType = "reservation.wrapup"
+	ReservationCompleted   TaskRouterEventType = "reservation.completed"
+	ReservationFailed      TaskRouterEventType = "reservation.failed"
+	WorkerCreated          TaskRouterEventType = "worker.created"
+	WorkerDeleted          TaskRouterEventType = "worker.deleted"
+	WorkerActivityUpdate   TaskRouterEventType = "worker.activity.update"
+	WorkerAttributesUpdate TaskRouterEventType = "worker.attributes.update"
+	WorkerCapacityUpdate   TaskRouterEventType = "worker.capacity.update"
+	WorkflowEntered        TaskRouterEventType = "workflow.entered"
+	WorkflowTargetMatched  TaskRouterEventType = "workflow.target-matched"
+	WorkflowTimeout        TaskRouterEventType = "workflow.timeout"
+	WorkflowSkipped        TaskRouterEventType = "workflow.skipped"
+	TaskQueueEntered       TaskRouterEventType = "task-queue.entered"
+	TaskQueueTimeout       TaskRouterEventType = "task-queue.timeout"
+	TaskQueueMoved         TaskRouterEventType = "task-queue.moved"
+)
+
+// A TaskRouterEvent holds the parameters of a TaskRouter event callback.
+// Which of the task, worker and reservation fields are set depends on
+// EventType. TaskAttributes and WorkerAttributes hold JSON, for
+// json.Unmarshal into a type of your own.
+type TaskRouterEvent struct {
+	Sid              string              `twilio:"Sid"`
+	EventType        TaskRouterEventType `twilio:"EventType"`
+	EventDescription string              `twilio:"EventDescription"`
+	AccountSid       string              `twilio:"AccountSid"`
+	WorkspaceSid     string              `twilio:"WorkspaceSid"`
+	WorkspaceName    string              `twilio:"WorkspaceName"`
+	ResourceType     string              `twilio:"ResourceType"`
+	ResourceSid      string              `twilio:"ResourceSid"`
+	Timestamp        time.Time           `twilio:"TimestampMs,unix,ms"`
+
+	TaskSid               string        `twilio:"TaskSid"`
+	TaskAttributes        string        `twilio:"TaskAttributes"`
+	TaskAge               time.Duration `twilio:"TaskAge"`
+	TaskPriority          int           `twilio:"TaskPriority"`
+	TaskAssignmentStatus  string        `twilio:"TaskAssignmentStatus"`
+	TaskCanceledReason    string        `twilio:"TaskCanceledReason"`
+	TaskCompletedReason   string        `twilio:"TaskCompletedReason"`
+	TaskChannelSid        string        `twilio:"TaskChannelSid"`
+	TaskChannelUniqueName string        `twilio:"TaskChannelUniqueName"`
+	TaskQueueSid          string        `twilio:"TaskQueueSid"`
+	TaskQueueName         string        `twilio:"TaskQueueName"`
+	WorkflowSid           string        `twilio:"WorkflowSid"`
+	WorkflowName          string        `twilio:"WorkflowName"`
+
+	WorkerSid                  string `twilio:"WorkerSid"`
+	WorkerName                 string `twilio:"WorkerName"`
+	WorkerAttributes           string `twilio:"WorkerAttributes"`
+	WorkerActivitySid          string `twilio:"WorkerActivitySid"`
+	WorkerActivityName         string `twilio:"WorkerActivityName"`
+	WorkerPreviousActivitySid  string `twilio:"WorkerPreviousActivitySid"`
+	WorkerPreviousActivityName string `twilio:"WorkerPreviousActivityName"`
+
+	ReservationSid string `twilio:"ReservationSid"`
+}
+
+// DecodeTaskRouterEvent decodes the parameters of a TaskRouter event
+// callback that has been validated by a twilio.Validator.
+func DecodeTaskRouterEvent(r *http.Request) (*TaskRouterEvent, error) {
+	return Decode[*TaskRouterEvent](r)
+}
+
+// A TaskRouterHandler dispatches TaskRouter event callbacks to functions
+// registered for their event types. Events with no function registered are
+// acknowledged with 200 OK.
+//
+// Example usage:
+//
+//	h := new(webhook.TaskRouterHandler)
+//	h.HandleFunc(webhook.TaskCanceled, func(w http.ResponseWriter, r *http.Request, ev *webhook.TaskRouterEvent) {
+//		log.Printf("task %s canceled: %s", ev.TaskSid, ev.TaskCanceledReason)
+//	})
+//	http.Handle("/taskrouter/events", v.Middleware(h))
+type TaskRouterHandler struct {
+	handlers map[TaskRouterEventType]func(http.ResponseWriter, *http.Request, *TaskRouterEvent)
+}
+
+// HandleFunc registers f for events of type event, replacing any function
+// registered for it before.
+func (h *TaskRouterHandler) HandleFunc(event TaskRouterEventType, f func(w http.ResponseWriter, r *http.Request, ev *TaskRouterEvent)) {
+	if h.handlers == nil {
+		h.handlers = make(map[TaskRouterEventType]func(http.ResponseWriter, *http.Request, *TaskRouterEvent))
+	}
+	h.handlers[event] = f
+}
+
+// ServeHTTP decodes the event in r and calls the function registered for
+// its type. Requests that can't be decoded get 400 Bad Request.
+func (h *TaskRouterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ev, err := DecodeTaskRouterEvent(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f := h.handlers[ev.EventType]; f != nil {
+		f(w, r, ev)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// An AssignmentCallback holds the parameters TaskRouter sends to a
+// Workflow's AssignmentCallbackUrl when it reserves a worker for a task.
+// The response tells TaskRouter what to do with the reservation; see
+// WriteAssignment.
+type AssignmentCallback struct {
+	AccountSid       string        `twilio:"AccountSid"`
+	WorkspaceSid     string        `twilio:"WorkspaceSid"`
+	WorkflowSid      string        `twilio:"WorkflowSid"`
+	TaskQueueSid     string        `twilio:"TaskQueueSid"`
+	TaskSid          string        `twilio:"TaskSid"`
+	TaskAttributes   string        `twilio:"TaskAttributes"`
+	TaskAge          time.Duration `twilio:"TaskAge"`
+	TaskPriority     int           `twilio:"TaskPriority"`
+	WorkerSid        string        `twilio:"WorkerSid"`
+	WorkerAttributes string        `twilio:"WorkerAttributes"`
+	ReservationSid   string        `twilio:"ReservationSid"`
+}
+
+// DecodeAssignment decodes the parameters of a TaskRouter assignment
+// callback that has been validated by a twilio.Validator.
+func DecodeAssignment(r *http.Request) (*AssignmentCallback, error) {
+	return Decode[*AssignmentCallback](r)
+}
+
+// An AssignmentInstruction is a response to an assignment callback:
+// AcceptInstruction, RejectInstruction, DequeueInstruction,
+// CallInstruction, ConferenceInstruction or RedirectInstruction.
+type AssignmentInstruction interface {
+	instruction() string
+}
+
+// AcceptInstruction accepts the reservation, leaving it to the app to
+// connect the worker to the task.
+type AcceptInstruction struct{}
+
+// RejectInstruction rejects the reservation, moving the worker to
+// ActivitySid, if set, so TaskRouter doesn't offer them the task again.
+type RejectInstruction struct {
+	ActivitySid string `json:"activity_sid,omitempty"`
+}
+
+// DequeueInstruction accepts the reservation and connects the task's
+// call, which must have been enqueued with Enqueue, to the worker's
+// contact_uri, or To if set. From is the caller ID presented to the
+// worker.
+type DequeueInstruction struct {
+	From                 string   `json:"from,omitempty"`
+	To                   string   `json:"to,omitempty"`
+	PostWorkActivitySid  string   `json:"post_work_activity_sid,omitempty"`
+	Timeout              int      `json:"timeout,omitempty"`
+	Record               string   `json:"record,omitempty"` // record-from-answer, for example
+	StatusCallbackURL    string   `json:"status_callback_url,omitempty"`
+	StatusCallbackEvents []string `json:"status_callback_events,omitempty"`
+}
+
+// CallInstruction accepts the reservation, if Accept is set, and calls the
+// worker, who is given the TwiML at URL when they answer.
+type CallInstruction struct {
+	URL               string `json:"url"`
+	From              string `json:"from"`
+	To                string `json:"to,omitempty"`
+	Accept            bool   `json:"accept,omitempty"`
+	Record            bool   `json:"record,omitempty"`
+	Timeout           int    `json:"timeout,omitempty"`
+	StatusCallbackURL string `json:"status_callback_url,omitempty"`
+}
+
+// ConferenceInstruction accepts the reservation and connects the task's
+// call and the worker in a conference.
+type ConferenceInstruction struct {
+	From                string `json:"from,omitempty"`
+	To                  string `json:"to,omitempty"`
+	PostWorkActivitySid string `json:"post_work_activity_sid,omitempty"`
+	Timeout             int    `json:"timeout,omitempty"`
+	Record              bool   `json:"record,omitempty"`
+	EndConferenceOnExit bool   `json:"end_conference_on_exit,omitempty"`
+}
+
+// RedirectInstruction redirects the call CallSid to the TwiML at URL,
+// accepting the reservation if Accept is set.
+type RedirectInstruction struct {
+	CallSid             string `json:"call_sid"`
+	URL                 string `json:"url"`
+	Accept              bool   `json:"accept,omitempty"`
+	PostWorkActivitySid string `json:"post_work_activity_sid,omitempty"`
+}
+
+func (AcceptInstruction) instruction() string     { return "accept" }
+func (RejectInstruction) instruction() string     { return "reject" }
+func (DequeueInstruction) instruction() string    { return "dequeue" }
+func (CallInstruction) instruction() string       { return "call" }
+func (ConferenceInstruction) instruction() string { return "conference" }
+func (RedirectInstruction) instruction() string   { return "redirect" }
+
+// WriteAssignment responds to an assignment callback with in, as the JSON
+// TaskRouter expects.
+//
+// Example usage:
+//
+//	func assign(w http.ResponseWriter, r *http.Request) {
+//		a, err := webhook.DecodeAssignment(r)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusBadRequest)
+//			return
+//		}
+//		webhook.WriteAssignment(w, webhook.DequeueInstruction{
+//			From:                "+14155550100",
+//			PostWorkActivitySid: wrapUpActivitySid,
+//		})
+//	}
+func WriteAssignment(w http.ResponseWriter, in AssignmentInstruction) error {
+	b, err := marshalInstruction(in)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(b)
+	return err
+}
+
+// marshalInstruction encodes in as a JSON object with its fields and an
+// "instruction" member naming its type.
+func marshalInstruction(in AssignmentInstruction) ([]byte, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	fields["instruction"], _ = json.Marshal(in.instruction())
+	return json.Marshal(fields)
+}
@@ -0,0 +1,143 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeTaskRouterEvent(t *testing.T) {
+	r := validated(t, url.Values{
+		"Sid":                {"EV123"},
+		"EventType":          {"reservation.accepted"},
+		"WorkspaceSid":       {"WS123"},
+		"ResourceType":       {"reservation"},
+		"ResourceSid":        {"WR123"},
+		"Timestamp":          {"1699315200"},
+		"TimestampMs":        {"1699315200250"},
+		"TaskSid":            {"WT123"},
+		"TaskAttributes":     {`{"language":"es"}`},
+		"TaskAge":            {"42"},
+		"TaskPriority":       {"5"},
+		"WorkerSid":          {"WK123"},
+		"WorkerActivityName": {"Busy"},
+		"ReservationSid":     {"WR123"},
+	})
+	got, err := webhook.DecodeTaskRouterEvent(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.TaskRouterEvent{
+		Sid:                "EV123",
+		EventType:          webhook.ReservationAccepted,
+		WorkspaceSid:       "WS123",
+		ResourceType:       "reservation",
+		ResourceSid:        "WR123",
+		Timestamp:          time.Date(2023, 11, 7, 0, 0, 0, 250e6, time.UTC),
+		TaskSid:            "WT123",
+		TaskAttributes:     `{"language":"es"}`,
+		TaskAge:            42 * time.Second,
+		TaskPriority:       5,
+		WorkerSid:          "WK123",
+		WorkerActivityName: "Busy",
+		ReservationSid:     "WR123",
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestTaskRouterHandler(t *testing.T) {
+	var h webhook.TaskRouterHandler
+	var canceled string
+	h.HandleFunc(webhook.TaskCanceled, func(w http.ResponseWriter, r *http.Request, ev *webhook.TaskRouterEvent) {
+		canceled = ev.TaskSid
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, validated(t, url.Values{"EventType": {"task.canceled"}, "TaskSid": {"WT123"}}))
+	if w.Code != http.StatusOK || canceled != "WT123" {
+		t.Errorf("task.canceled: got status %d and TaskSid %q", w.Code, canceled)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, validated(t, url.Values{"EventType": {"worker.created"}}))
+	if w.Code != http.StatusOK {
+		t.Errorf("unhandled event: got status %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, validated(t, url.Values{"EventType": {"task.created"}, "TaskAge": {"old"}}))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad TaskAge: got status %d, want 400", w.Code)
+	}
+}
+
+func TestDecodeAssignment(t *testing.T) {
+	r := validated(t, url.Values{
+		"TaskSid":          {"WT123"},
+		"TaskAttributes":   {`{"call_sid":"CA123"}`},
+		"WorkerSid":        {"WK123"},
+		"WorkerAttributes": {`{"contact_uri":"client:alice"}`},
+		"ReservationSid":   {"WR123"},
+	})
+	got, err := webhook.DecodeAssignment(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.AssignmentCallback{
+		TaskSid:          "WT123",
+		TaskAttributes:   `{"call_sid":"CA123"}`,
+		WorkerSid:        "WK123",
+		WorkerAttributes: `{"contact_uri":"client:alice"}`,
+		ReservationSid:   "WR123",
+	}
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestWriteAssignment(t *testing.T) {
+	tests := []struct {
+		in   webhook.AssignmentInstruction
+		want map[string]any
+	}{
+		{webhook.AcceptInstruction{}, map[string]any{"instruction": "accept"}},
+		{webhook.RejectInstruction{ActivitySid: "WA1"}, map[string]any{"instruction": "reject", "activity_sid": "WA1"}},
+		{
+			webhook.DequeueInstruction{From: "+14155550100", PostWorkActivitySid: "WA2", Timeout: 20},
+			map[string]any{"instruction": "dequeue", "from": "+14155550100", "post_work_activity_sid": "WA2", "timeout": 20.0},
+		},
+		{
+			webhook.CallInstruction{URL: "https://example.com/agent", From: "+14155550100", Accept: true},
+			map[string]any{"instruction": "call", "url": "https://example.com/agent", "from": "+14155550100", "accept": true},
+		},
+		{
+			webhook.RedirectInstruction{CallSid: "CA123", URL: "https://example.com/hold"},
+			map[string]any{"instruction": "redirect", "call_sid": "CA123", "url": "https://example.com/hold"},
+		},
+		{webhook.ConferenceInstruction{EndConferenceOnExit: true}, map[string]any{"instruction": "conference", "end_conference_on_exit": true}},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		if err := webhook.WriteAssignment(w, test.in); err != nil {
+			t.Fatal(err)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%T: got Content-Type %q", test.in, ct)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T: got %v, want %v", test.in, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+)
+
+// A UsageTriggerBy is the measure of usage a usage trigger watches.
+type UsageTriggerBy string
+
+const (
+	TriggerByCount UsageTriggerBy = "count" // number of calls, messages and so on
+	TriggerByUsage UsageTriggerBy = "usage" // in the category's unit, such as minutes
+	TriggerByPrice UsageTriggerBy = "price" // in the account's currency
+)
+
+// A UsageTriggerCallback holds the parameters Twilio sends to a usage
+// trigger's CallbackUrl when an account's usage in UsageCategory reaches
+// TriggerValue. CurrentValue is the usage at the time it fired, which may
+// already be past TriggerValue.
+//
+// Twilio retries callbacks it doesn't get a response to, with the same
+// IdempotencyToken; a twilio.Validator with replay protection rejects the
+// retries of callbacks it has already accepted.
+//
+// Example usage:
+//
+//	t, err := webhook.DecodeUsageTrigger(r)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	if t.UsageCategory == "totalprice" {
+//		suspendOutboundCalls(t.AccountSid)
+//	}
+type UsageTriggerCallback struct {
+	AccountSid       string         `twilio:"AccountSid"`
+	UsageTriggerSid  string         `twilio:"UsageTriggerSid"`
+	DateFired        time.Time      `twilio:"DateFired"`
+	Recurring        string         `twilio:"Recurring"` // daily, monthly, yearly or alltime
+	UsageCategory    string         `twilio:"UsageCategory"`
+	TriggerBy        UsageTriggerBy `twilio:"TriggerBy"`
+	TriggerValue     float64        `twilio:"TriggerValue"`
+	CurrentValue     float64        `twilio:"CurrentValue"`
+	UsageRecordURI   string         `twilio:"UsageRecordUri"`
+	IdempotencyToken string         `twilio:"IdempotencyToken"`
+}
+
+// DecodeUsageTrigger decodes the parameters of a usage trigger callback
+// that has been validated by a twilio.Validator.
+func DecodeUsageTrigger(r *http.Request) (*UsageTriggerCallback, error) {
+	return Decode[*UsageTriggerCallback](r)
+}
@@ -0,0 +1,47 @@
+package webhook_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeUsageTrigger(t *testing.T) {
+	r := validated(t, url.Values{
+		"AccountSid":       {"AC123"},
+		"UsageTriggerSid":  {"UT456"},
+		"DateFired":        {"Wed, 01 May 2024 12:00:00 +0000"},
+		"Recurring":        {"monthly"},
+		"UsageCategory":    {"totalprice"},
+		"TriggerBy":        {"price"},
+		"TriggerValue":     {"100.00"},
+		"CurrentValue":     {"100.25"},
+		"UsageRecordUri":   {"/2010-04-01/Accounts/AC123/Usage/Records/ThisMonth?Category=totalprice"},
+		"IdempotencyToken": {"AC123-FIRES-UT456-2024-05-01"},
+	})
+	got, err := webhook.DecodeUsageTrigger(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.UsageTriggerCallback{
+		AccountSid:       "AC123",
+		UsageTriggerSid:  "UT456",
+		DateFired:        time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Recurring:        "monthly",
+		UsageCategory:    "totalprice",
+		TriggerBy:        webhook.TriggerByPrice,
+		TriggerValue:     100,
+		CurrentValue:     100.25,
+		UsageRecordURI:   "/2010-04-01/Accounts/AC123/Usage/Records/ThisMonth?Category=totalprice",
+		IdempotencyToken: "AC123-FIRES-UT456-2024-05-01",
+	}
+	if !got.DateFired.Equal(want.DateFired) {
+		t.Errorf("DateFired = %v, want %v", got.DateFired, want.DateFired)
+	}
+	got.DateFired = want.DateFired
+	if *got != want {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
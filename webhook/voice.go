@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// A VoiceRequest holds the parameters Twilio sends to a voice webhook when
+// a call comes in or an outgoing call connects.
+type VoiceRequest struct {
+	CallSid       string      `twilio:"CallSid"`
+	AccountSid    string      `twilio:"AccountSid"`
+	From          PhoneNumber `twilio:"From"`
+	To            PhoneNumber `twilio:"To"`
+	CallStatus    CallStatus  `twilio:"CallStatus"`
+	Direction     Direction   `twilio:"Direction"`
+	APIVersion    string      `twilio:"ApiVersion"`
+	ForwardedFrom PhoneNumber `twilio:"ForwardedFrom"`
+	CallerName    string      `twilio:"CallerName"` // with caller ID lookup enabled
+	ParentCallSid string      `twilio:"ParentCallSid"`
+	CallToken     string      `twilio:"CallToken"`
+	FromGeo       Geo         `twilio:"From"`
+	ToGeo         Geo         `twilio:"To"`
+
+	// The Sip fields are set for calls to a SIP domain.
+	SipCallID   string `twilio:"SipCallId"`
+	SipDomain   string `twilio:"SipDomain"`
+	SipSourceIP string `twilio:"SipSourceIp"`
+
+	// StirVerstat and StirPassportToken are set for incoming calls signed
+	// with STIR/SHAKEN. StirPassportToken is the signed PASSporT JWT.
+	StirVerstat       StirVerstat `twilio:"StirVerstat"`
+	StirPassportToken string      `twilio:"StirPassportToken"`
+
+	// MachineDetection is set for outgoing calls placed with answering
+	// machine detection.
+	MachineDetection
+
+	sipHeaders map[string]string
+}
+
+// sipHeaderPrefix starts the names of the parameters holding a SIP call's
+// custom headers.
+const sipHeaderPrefix = "SipHeader_"
+
+// SipHeaders returns the custom X- headers of the INVITE that started a
+// call to a SIP domain, keyed by header name, which Twilio sends as
+// SipHeader_ parameters. It returns nil for calls with none.
+//
+// Example usage:
+//
+//	if ref := call.SipHeaders()["X-Customer-Ref"]; ref != "" {
+//		...
+//	}
+func (c *VoiceRequest) SipHeaders() map[string]string {
+	return maps.Clone(c.sipHeaders)
+}
+
+func (c *VoiceRequest) decodeParams(p url.Values) error {
+	c.sipHeaders = nil
+	for k := range p {
+		if name, ok := strings.CutPrefix(k, sipHeaderPrefix); ok && name != "" {
+			if c.sipHeaders == nil {
+				c.sipHeaders = make(map[string]string)
+			}
+			c.sipHeaders[name] = p.Get(k)
+		}
+	}
+	return nil
+}
+
+// DecodeVoice decodes the parameters of a voice webhook request that has
+// been validated by a twilio.Validator.
+func DecodeVoice(r *http.Request) (*VoiceRequest, error) {
+	return Decode[*VoiceRequest](r)
+}
+
+// A CallStatusCallback holds the parameters Twilio sends to a call's
+// StatusCallback as the call progresses.
+type CallStatusCallback struct {
+	VoiceRequest
+	CallDuration      time.Duration `twilio:"CallDuration"`
+	Duration          int           `twilio:"Duration"` // billable minutes
+	SequenceNumber    int           `twilio:"SequenceNumber"`
+	Timestamp         time.Time     `twilio:"Timestamp"`
+	CallbackSource    string        `twilio:"CallbackSource"`
+	SipResponseCode   int           `twilio:"SipResponseCode"`
+	RecordingURL      string        `twilio:"RecordingUrl"`
+	RecordingSid      string        `twilio:"RecordingSid"`
+	RecordingDuration time.Duration `twilio:"RecordingDuration"`
+}
+
+// DecodeCallStatus decodes the parameters of a call status callback
+// request that has been validated by a twilio.Validator.
+func DecodeCallStatus(r *http.Request) (*CallStatusCallback, error) {
+	return Decode[*CallStatusCallback](r)
+}
+
+// A GatherCallback holds the parameters Twilio sends to a Gather's Action
+// with what the caller entered: the keys they pressed in Digits, or what
+// they said in SpeechResult, with Twilio's Confidence in it between 0 and
+// 1.
+type GatherCallback struct {
+	VoiceRequest
+	Digits        string  `twilio:"Digits"`
+	FinishedOnKey string  `twilio:"FinishedOnKey"`
+	SpeechResult  string  `twilio:"SpeechResult"`
+	Confidence    float64 `twilio:"Confidence"`
+}
+
+// DecodeGather decodes the parameters of a Gather action request that has
+// been validated by a twilio.Validator.
+func DecodeGather(r *http.Request) (*GatherCallback, error) {
+	return Decode[*GatherCallback](r)
+}
+
+// A DialCallback holds the parameters Twilio sends to a Dial's Action when
+// the dialed call ends: its outcome in DialCallStatus, such as completed,
+// busy or no-answer, and whether the two calls were ever connected in
+// DialBridged.
+type DialCallback struct {
+	VoiceRequest
+	DialCallStatus      CallStatus    `twilio:"DialCallStatus"`
+	DialCallSid         string        `twilio:"DialCallSid"`
+	DialCallDuration    time.Duration `twilio:"DialCallDuration"`
+	DialBridged         bool          `twilio:"DialBridged"`
+	DialSipResponseCode int           `twilio:"DialSipResponseCode"`
+	RecordingURL        string        `twilio:"RecordingUrl"`
+}
+
+// DecodeDial decodes the parameters of a Dial action request that has
+// been validated by a twilio.Validator.
+func DecodeDial(r *http.Request) (*DialCallback, error) {
+	return Decode[*DialCallback](r)
+}
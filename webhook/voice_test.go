@@ -0,0 +1,180 @@
+package webhook_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+func TestDecodeVoice(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":       {"CA123"},
+		"AccountSid":    {"AC456"},
+		"From":          {"+14155550199"},
+		"To":            {"+14155550100"},
+		"CallStatus":    {"ringing"},
+		"Direction":     {"inbound"},
+		"ApiVersion":    {"2010-04-01"},
+		"ForwardedFrom": {"+14155550101"},
+		"CallerName":    {"ADA LOVELACE"},
+	})
+	got, err := webhook.DecodeVoice(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.VoiceRequest{
+		CallSid:       "CA123",
+		AccountSid:    "AC456",
+		From:          "+14155550199",
+		To:            "+14155550100",
+		CallStatus:    webhook.CallRinging,
+		Direction:     webhook.DirectionInbound,
+		APIVersion:    "2010-04-01",
+		ForwardedFrom: "+14155550101",
+		CallerName:    "ADA LOVELACE",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeVoiceSip(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":                  {"CA123"},
+		"From":                     {"sip:alice@example.com"},
+		"To":                       {"sip:+14155550100@example.sip.twilio.com"},
+		"SipCallId":                {"a84b4c76e66710@pc33.example.com"},
+		"SipDomain":                {"example.sip.twilio.com"},
+		"SipSourceIp":              {"203.0.113.7"},
+		"SipHeader_X-Customer-Ref": {"42"},
+		"SipHeader_X-Account-Tier": {"gold"},
+		"SipHeader_":               {"ignored"},
+	})
+	got, err := webhook.DecodeCallStatus(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SipCallID != "a84b4c76e66710@pc33.example.com" || got.SipDomain != "example.sip.twilio.com" || got.SipSourceIP != "203.0.113.7" {
+		t.Errorf("got %+v", got.VoiceRequest)
+	}
+	want := map[string]string{"X-Customer-Ref": "42", "X-Account-Tier": "gold"}
+	if h := got.SipHeaders(); !reflect.DeepEqual(h, want) {
+		t.Errorf("SipHeaders() = %v, want %v", h, want)
+	}
+	got.SipHeaders()["X-Customer-Ref"] = "changed"
+	if got.SipHeaders()["X-Customer-Ref"] != "42" {
+		t.Error("SipHeaders returned the request's own map")
+	}
+
+	call, err := webhook.DecodeVoice(validated(t, url.Values{"CallSid": {"CA123"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := call.SipHeaders(); h != nil {
+		t.Errorf("SipHeaders() = %v, want nil", h)
+	}
+}
+
+func TestDecodeCallStatus(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":         {"CA123"},
+		"ParentCallSid":   {"CA000"},
+		"CallStatus":      {"completed"},
+		"Direction":       {"outbound-dial"},
+		"CallDuration":    {"42"},
+		"Duration":        {"1"},
+		"SequenceNumber":  {"3"},
+		"Timestamp":       {"Tue, 07 Nov 2023 19:50:55 +0000"},
+		"CallbackSource":  {"call-progress-events"},
+		"SipResponseCode": {"200"},
+	})
+	got, err := webhook.DecodeCallStatus(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.CallStatusCallback{
+		VoiceRequest: webhook.VoiceRequest{
+			CallSid:       "CA123",
+			ParentCallSid: "CA000",
+			CallStatus:    webhook.CallCompleted,
+			Direction:     webhook.DirectionOutboundDial,
+		},
+		CallDuration:    42 * time.Second,
+		Duration:        1,
+		SequenceNumber:  3,
+		Timestamp:       time.Date(2023, 11, 7, 19, 50, 55, 0, time.UTC),
+		CallbackSource:  "call-progress-events",
+		SipResponseCode: 200,
+	}
+	if !got.Timestamp.Equal(want.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, want.Timestamp)
+	}
+	got.Timestamp = want.Timestamp
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+
+	r = validated(t, url.Values{"CallSid": {"CA123"}, "Timestamp": {"yesterday"}})
+	if _, err := webhook.DecodeCallStatus(r); err == nil {
+		t.Error("a bad Timestamp should fail to decode")
+	}
+}
+
+func TestDecodeGather(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":       {"CA123"},
+		"From":          {"+14155550199"},
+		"Digits":        {"12"},
+		"FinishedOnKey": {"#"},
+		"SpeechResult":  {"sales"},
+		"Confidence":    {"0.92"},
+	})
+	got, err := webhook.DecodeGather(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.GatherCallback{
+		VoiceRequest:  webhook.VoiceRequest{CallSid: "CA123", From: "+14155550199"},
+		Digits:        "12",
+		FinishedOnKey: "#",
+		SpeechResult:  "sales",
+		Confidence:    0.92,
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestDecodeDial(t *testing.T) {
+	r := validated(t, url.Values{
+		"CallSid":          {"CA123"},
+		"DialCallStatus":   {"no-answer"},
+		"DialCallSid":      {"CA789"},
+		"DialCallDuration": {"0"},
+		"DialBridged":      {"false"},
+	})
+	got, err := webhook.DecodeDial(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webhook.DialCallback{
+		VoiceRequest:   webhook.VoiceRequest{CallSid: "CA123"},
+		DialCallStatus: webhook.CallNoAnswer,
+		DialCallSid:    "CA789",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v\nwant %+v", *got, want)
+	}
+
+	r = validated(t, url.Values{"DialCallStatus": {"completed"}, "DialCallDuration": {"30"}, "DialBridged": {"true"}, "RecordingUrl": {"https://example.com/r"}})
+	got, err = webhook.DecodeDial(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.DialBridged || got.DialCallDuration != 30*time.Second || got.RecordingURL != "https://example.com/r" {
+		t.Errorf("got %+v", *got)
+	}
+}
@@ -0,0 +1,307 @@
+// Package webhook decodes the parameters of Twilio webhook requests into
+// typed structs.
+//
+// The decoders read the parameters a twilio.Validator stored in the
+// request's context, so they only work behind one: wrap handlers with the
+// Validator's middleware first, and decode inside them.
+//
+// Example usage:
+//
+//	v := twilio.New(myAuthToken)
+//	http.Handle("/voice", v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//		call, err := webhook.DecodeVoice(r)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusBadRequest)
+//			return
+//		}
+//		log.Printf("call %s from %s", call.CallSid, call.From)
+//		...
+//	})))
+//
+// Decode and DecodeInto fill structs of your own, for parameters this
+// package doesn't have a struct for or when you only need a few of them.
+package webhook
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+)
+
+// ErrNotValidated is returned by the decoders for requests that didn't
+// pass through a twilio.Validator, or that failed validation.
+var ErrNotValidated = errors.New("webhook: request was not validated by a twilio.Validator")
+
+// ErrUnsignedBody is returned when a JSON webhook's URL has no bodySHA256
+// parameter, so its body isn't covered by the signature.
+var ErrUnsignedBody = errors.New("webhook: JSON webhook without bodySHA256 parameter")
+
+// params returns the webhook parameters of r, which must have been
+// validated, or skipped, by a twilio.Validator.
+func params(r *http.Request) (url.Values, error) {
+	res, ok := twilio.FromContext(r.Context())
+	if !ok || !(res.Valid || res.Skipped) {
+		return nil, ErrNotValidated
+	}
+	if res.Params != nil {
+		return res.Params, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return r.Form, nil
+}
+
+// decodeJSON decodes the JSON body of r into v, with numbers in
+// interface values decoded as json.Number. r must have been validated
+// by a twilio.Validator, with the body's hash in its signed URL, or
+// skipped by one.
+func decodeJSON(r *http.Request, v any) error {
+	res, ok := twilio.FromContext(r.Context())
+	if !ok || !(res.Valid || res.Skipped) {
+		return ErrNotValidated
+	}
+	if res.Valid && !r.URL.Query().Has(twilio.ParamBodySHA256) {
+		return ErrUnsignedBody
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	return nil
+}
+
+// DecodeInto decodes the parameters of r, which must have been validated
+// by a twilio.Validator, into v, which must be a pointer to a struct. Each
+// field tagged with `twilio:"Name"` is set from the parameter Name.
+// Fields can be strings, integers, floats or bools, a time.Duration given
+// in seconds, a time.Time, or any type that implements
+// encoding.TextUnmarshaler. Parameters that are missing or empty leave
+// their fields unchanged.
+//
+// Tags can have options after the name: layout gives the layout of a
+// time.Time parameter for time.Parse, when it isn't in one of the formats
+// Twilio usually uses, unix marks a time.Time parameter given as seconds
+// since the Unix epoch, and ms marks a time.Duration or unix time.Time
+// parameter given in milliseconds. Embedded structs are decoded from the
+// same parameters, and tagged struct fields from parameters whose names
+// start with the tag.
+//
+// Example usage:
+//
+//	var p struct {
+//		CallSid  string        `twilio:"CallSid"`
+//		Digits   string        `twilio:"Digits"`
+//		Duration time.Duration `twilio:"CallDuration"`
+//	}
+//	if err := webhook.DecodeInto(r, &p); err != nil {
+//		...
+//	}
+func DecodeInto(r *http.Request, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("webhook: DecodeInto needs a non-nil pointer to a struct, not %T", v)
+	}
+	p, err := params(r)
+	if err != nil {
+		return err
+	}
+	return decode(p, rv.Elem(), "")
+}
+
+// Decode decodes the parameters of r, which must have been validated by a
+// twilio.Validator, into a new T, as DecodeInto does. T must be a struct
+// or a pointer to one.
+//
+// Example usage:
+//
+//	type menuChoice struct {
+//		CallSid string `twilio:"CallSid"`
+//		Digits  string `twilio:"Digits"`
+//	}
+//
+//	choice, err := webhook.Decode[menuChoice](r)
+func Decode[T any](r *http.Request) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	target := any(&v)
+	if rv.Kind() == reflect.Pointer {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		target = v
+	}
+	if err := DecodeInto(r, target); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// decode sets the fields of the struct v from p as described for Decode,
+// with each parameter name preceded by prefix.
+func decode(p url.Values, v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("twilio")
+		if !tagged {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				if err := decode(p, v.Field(i), prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, opts := parseTag(tag)
+		name = prefix + name
+		if nested(f.Type) {
+			if err := decode(p, v.Field(i), name); err != nil {
+				return err
+			}
+			continue
+		}
+		s := p.Get(name)
+		if s == "" {
+			continue
+		}
+		if err := set(v.Field(i), s, opts); err != nil {
+			return fmt.Errorf("webhook: %s: %w", name, err)
+		}
+	}
+	if v.Addr().CanInterface() {
+		if d, ok := v.Addr().Interface().(paramDecoder); ok {
+			return d.decodeParams(p)
+		}
+	}
+	return nil
+}
+
+// A paramDecoder is a struct with parameters its tags can't describe, such
+// as numbered ones. decode calls decodeParams once the tagged fields are
+// set.
+type paramDecoder interface {
+	decodeParams(p url.Values) error
+}
+
+// tagOptions are the options that can follow the parameter name in a
+// twilio tag.
+type tagOptions struct {
+	layout string
+	unix   bool
+	unit   time.Duration
+}
+
+func parseTag(tag string) (string, tagOptions) {
+	name, rest, _ := strings.Cut(tag, ",")
+	opts := tagOptions{unit: time.Second}
+	for _, opt := range strings.Split(rest, ",") {
+		if layout, ok := strings.CutPrefix(opt, "layout="); ok {
+			opts.layout = layout
+		} else if opt == "unix" {
+			opts.unix = true
+		} else if opt == "ms" {
+			opts.unit = time.Millisecond
+		}
+	}
+	return name, opts
+}
+
+// timeFormats are the layouts of the timestamps in webhook parameters.
+var timeFormats = []string{time.RFC1123Z, time.RFC1123, time.RFC3339}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// nested reports whether fields of type t hold several parameters rather
+// than one.
+func nested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// set parses s into the field v.
+func set(v reflect.Value, s string, opts tagOptions) error {
+	switch v.Type() {
+	case durationType:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n * float64(opts.unit)))
+		return nil
+	case timeType:
+		if opts.unix {
+			var ns int64
+			if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+				ns = n * int64(opts.unit)
+			} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+				ns = int64(f * float64(opts.unit))
+			} else {
+				return err
+			}
+			v.Set(reflect.ValueOf(time.Unix(0, ns).UTC()))
+			return nil
+		}
+		layouts := timeFormats
+		if opts.layout != "" {
+			layouts = []string{opts.layout}
+		}
+		var err error
+		for _, layout := range layouts {
+			var t time.Time
+			if t, err = time.Parse(layout, s); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return err
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("can't decode into %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package webhook_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeremyschlatter/twilio-middleware"
+	"github.com/jeremyschlatter/twilio-middleware/webhook"
+)
+
+// validated returns a request with params as its POST form, as a handler
+// behind a twilio.Validator sees it.
+func validated(t *testing.T, params url.Values) *http.Request {
+	t.Helper()
+	const target = "https://example.com/hook"
+	r := httptest.NewRequest("POST", target, strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Twilio-Signature-256", twilio.ComputeSignatureSHA256([]byte("12345"), target, params))
+	var passed *http.Request
+	twilio.New("12345").Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		passed = r
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	if passed == nil {
+		t.Fatal("request failed validation")
+	}
+	return passed
+}
+
+func TestNotValidated(t *testing.T) {
+	r := httptest.NewRequest("POST", "/hook", strings.NewReader("CallSid=CA123"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := webhook.DecodeVoice(r); !errors.Is(err, webhook.ErrNotValidated) {
+		t.Errorf("got %v, want ErrNotValidated", err)
+	}
+}
+
+func TestSkipped(t *testing.T) {
+	r := httptest.NewRequest("POST", "/health?CallSid=CA123", nil)
+	var passed *http.Request
+	twilio.New("12345", twilio.ExemptPaths("/health")).Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		passed = r
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	call, err := webhook.DecodeVoice(passed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.CallSid != "CA123" {
+		t.Errorf("CallSid = %q, want CA123", call.CallSid)
+	}
+}
+
+// level implements encoding.TextUnmarshaler.
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func TestDecodeInto(t *testing.T) {
+	type geo struct {
+		City string `twilio:"City"`
+	}
+	type common struct {
+		AccountSid string `twilio:"AccountSid"`
+	}
+	var got struct {
+		common
+		CallSid  string        `twilio:"CallSid"`
+		Segments uint8         `twilio:"NumSegments"`
+		Price    float64       `twilio:"Price"`
+		Bridged  bool          `twilio:"DialBridged"`
+		Duration time.Duration `twilio:"CallDuration"`
+		Wait     time.Duration `twilio:"WaitMs,ms"`
+		When     time.Time     `twilio:"Timestamp"`
+		Day      time.Time     `twilio:"Day,layout=2006-01-02"`
+		Epoch    time.Time     `twilio:"Epoch,unix"`
+		EpochMs  time.Time     `twilio:"EpochMs,unix,ms"`
+		Level    level         `twilio:"Level"`
+		From     geo           `twilio:"From"`
+		Missing  string        `twilio:"Missing"`
+		Ignored  string
+	}
+	got.Missing = "unchanged"
+	r := validated(t, url.Values{
+		"AccountSid":   {"AC456"},
+		"CallSid":      {"CA123"},
+		"NumSegments":  {"3"},
+		"Price":        {"-0.0075"},
+		"DialBridged":  {"true"},
+		"CallDuration": {"61"},
+		"WaitMs":       {"1500"},
+		"Timestamp":    {"2023-11-07T19:50:55Z"},
+		"Day":          {"2023-11-07"},
+		"Epoch":        {"1699315200"},
+		"EpochMs":      {"1699315200500"},
+		"Level":        {"high"},
+		"FromCity":     {"OAKLAND"},
+		"Ignored":      {"x"},
+	})
+	if err := webhook.DecodeInto(r, &got); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2023, 11, 7, 0, 0, 0, 0, time.UTC)
+	switch {
+	case got.AccountSid != "AC456", got.CallSid != "CA123", got.Segments != 3, got.Price != -0.0075, !got.Bridged:
+		t.Errorf("got %+v", got)
+	case got.Duration != 61*time.Second, got.Wait != 1500*time.Millisecond:
+		t.Errorf("durations: got %v and %v", got.Duration, got.Wait)
+	case !got.When.Equal(day.Add(19*time.Hour + 50*time.Minute + 55*time.Second)), !got.Day.Equal(day):
+		t.Errorf("times: got %v and %v", got.When, got.Day)
+	case !got.Epoch.Equal(day), !got.EpochMs.Equal(day.Add(500 * time.Millisecond)):
+		t.Errorf("unix times: got %v and %v", got.Epoch, got.EpochMs)
+	case got.Level != 2, got.From.City != "OAKLAND", got.Missing != "unchanged", got.Ignored != "":
+		t.Errorf("got %+v", got)
+	}
+
+	var bad struct {
+		Level level `twilio:"Level"`
+	}
+	if err := webhook.DecodeInto(validated(t, url.Values{"Level": {"medium"}}), &bad); err == nil || !strings.Contains(err.Error(), "webhook: Level:") {
+		t.Errorf("got %v, want an error about Level", err)
+	}
+	if err := webhook.DecodeInto(r, bad); err == nil {
+		t.Error("DecodeInto a struct value should fail")
+	}
+	var unsupported struct {
+		C chan int `twilio:"CallSid"`
+	}
+	if err := webhook.DecodeInto(r, &unsupported); err == nil {
+		t.Error("DecodeInto an unsupported field type should fail")
+	}
+}
+
+func TestDecodeGeneric(t *testing.T) {
+	type choice struct {
+		CallSid string `twilio:"CallSid"`
+		Digits  string `twilio:"Digits"`
+	}
+	r := validated(t, url.Values{"CallSid": {"CA123"}, "Digits": {"2"}})
+	got, err := webhook.Decode[choice](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (choice{"CA123", "2"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	ptr, err := webhook.Decode[*choice](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (choice{"CA123", "2"}); *ptr != want {
+		t.Errorf("got %+v, want %+v", *ptr, want)
+	}
+
+	if _, err := webhook.Decode[string](r); err == nil {
+		t.Error("Decode[string] should fail")
+	}
+	r = httptest.NewRequest("POST", "/hook", nil)
+	if got, err := webhook.Decode[*choice](r); !errors.Is(err, webhook.ErrNotValidated) || got != nil {
+		t.Errorf("got %v, %v, want nil and ErrNotValidated", got, err)
+	}
+}